@@ -0,0 +1,200 @@
+package cc
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// Options configures an Analyzer. The zero value analyzes for the
+// default data model with every rule enabled and no limits.
+type Options struct {
+	Model          DataModel             // target data model; the zero value means DefaultModel
+	Handles        map[string]bool       // see Env.Handles
+	SmartPointers  map[string]bool       // see Env.SmartPointers
+	AllowNarrowing map[string]bool       // see Env.AllowNarrowing
+	Enable         map[CastCategory]bool // see Env.Enable
+	MaxVisits      int                   // see ClassifyOptions; zero means no limit
+	Diff           DiffOptions           // options for DiffFiles
+
+	// Exclude lists the glob patterns, as for filepath.Match, of the
+	// files and directories AnalyzeTree skips. A pattern matches either
+	// the path relative to the root or the base name, so "vendor" skips
+	// every vendor directory and "gen/*.c" only the sources in gen.
+	Exclude []string
+}
+
+// An Analyzer bundles parsing, building the environment and classifying
+// casts, for tools embedding castdiff. It is safe for concurrent use.
+type Analyzer struct {
+	opts Options
+}
+
+// ErrTruncated is returned with the partial findings of an analysis
+// that used up Options.MaxVisits.
+var ErrTruncated = errors.New("analysis truncated")
+
+// New returns an Analyzer configured by opts.
+func New(opts Options) *Analyzer {
+	return &Analyzer{opts: opts}
+}
+
+// AnalyzeFile parses the C file at path and returns its classified
+// casts, as ClassifyCasts does. If the analysis was cut short by
+// MaxVisits, the findings so far are returned with ErrTruncated.
+func (a *Analyzer) AnalyzeFile(path string) ([]CastInfo, error) {
+	prog, err := parseFile(path)
+	if err != nil {
+		return nil, err
+	}
+	infos, truncated := ClassifyCastsLimited(prog, a.env(prog), ClassifyOptions{MaxVisits: a.opts.MaxVisits})
+	if truncated {
+		return infos, ErrTruncated
+	}
+	return infos, nil
+}
+
+// treeExts are the extensions of the files AnalyzeTree analyzes.
+var treeExts = map[string]bool{
+	".c":   true,
+	".cc":  true,
+	".cpp": true,
+	".cu":  true,
+	".h":   true,
+}
+
+// AnalyzeTree analyzes every C, C++, CUDA and header file under root,
+// other than those excluded by opts.Exclude, as AnalyzeFile does. Files
+// are analyzed concurrently. The findings are returned in file order
+// with file names relative to root. If the analysis of any file was cut
+// short by opts.MaxVisits, all findings are returned with ErrTruncated;
+// any other error stops the analysis.
+func AnalyzeTree(root string, opts Options) ([]CastInfo, error) {
+	files, err := treeFiles(root, opts.Exclude)
+	if err != nil {
+		return nil, err
+	}
+	type result struct {
+		i     int
+		infos []CastInfo
+		err   error
+	}
+	a := New(opts)
+	jobs := make(chan int)
+	results := make(chan result)
+	for w := 0; w < runtime.GOMAXPROCS(0); w++ {
+		go func() {
+			for i := range jobs {
+				infos, err := a.AnalyzeFile(filepath.Join(root, files[i]))
+				results <- result{i, infos, err}
+			}
+		}()
+	}
+	go func() {
+		for i := range files {
+			jobs <- i
+		}
+		close(jobs)
+	}()
+
+	perFile := make([][]CastInfo, len(files))
+	var firstErr error
+	for range files {
+		r := <-results
+		if r.err != nil && (firstErr == nil || firstErr == ErrTruncated) {
+			firstErr = r.err
+		}
+		perFile[r.i] = r.infos
+	}
+	if firstErr != nil && firstErr != ErrTruncated {
+		return nil, firstErr
+	}
+	var infos []CastInfo
+	for _, fi := range perFile {
+		infos = append(infos, fi...)
+	}
+	return DiffOptions{PathRoot: root}.normCasts(infos), firstErr
+}
+
+// treeFiles returns the paths relative to root of the files under root
+// that AnalyzeTree analyzes, in lexical order.
+func treeFiles(root string, exclude []string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if rel != "." && excluded(rel, exclude) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.IsDir() && treeExts[filepath.Ext(path)] {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// excluded reports whether the relative path rel matches one of the
+// patterns, by its full path or its base name.
+func excluded(rel string, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := filepath.Match(p, rel); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(p, filepath.Base(rel)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffFiles parses two versions of a C file and returns the casts that
+// differ between them, as Diff does with Options.Diff. The files are
+// compared as versions of one file whatever their names, and the
+// reported casts are classified as by AnalyzeFile.
+func (a *Analyzer) DiffFiles(oldPath, newPath string) ([]CastChange, error) {
+	old, err := parseFile(oldPath)
+	if err != nil {
+		return nil, err
+	}
+	new, err := parseFile(newPath)
+	if err != nil {
+		return nil, err
+	}
+	opts := a.opts.Diff
+	opts.SingleFile = true
+	opts.Classify = true
+	opts.Env = a.env
+	return Diff(old, new, opts), nil
+}
+
+// env builds the environment of prog configured by the options of a.
+func (a *Analyzer) env(prog *Prog) *Env {
+	env := BuildEnv(prog, a.opts.Model)
+	env.Handles = a.opts.Handles
+	env.SmartPointers = a.opts.SmartPointers
+	env.AllowNarrowing = a.opts.AllowNarrowing
+	env.Enable = a.opts.Enable
+	return env
+}
+
+// parseFile parses the file at path.
+func parseFile(path string) (*Prog, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	parseMu.Lock()
+	defer parseMu.Unlock()
+	return Read(path, f)
+}
@@ -0,0 +1,85 @@
+package cc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "castdiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	oldPath := filepath.Join(dir, "old.c")
+	newPath := filepath.Join(dir, "new.c")
+	if err := ioutil.WriteFile(oldPath, []byte("float to_float(double d) { return (float)d; }\nint f(double d) { return (int)d; }\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(newPath, []byte("float to_float(double d) { return (float)d; }\nint f(double d) { return (float)d; }\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	a := New(Options{AllowNarrowing: map[string]bool{"to_float": true}})
+	infos, err := a.AnalyzeFile(oldPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 2 || infos[0].Category != PlainCast || infos[1].Func != "f" {
+		t.Errorf("AnalyzeFile = %+v, want an allowed narrowing in to_float and a cast in f", infos)
+	}
+
+	changes, err := a.DiffFiles(oldPath, newPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Kind != Modified || changes[0].New.Category != FloatNarrowing {
+		t.Errorf("DiffFiles = %+v, want (int)d changed to a FloatNarrowing (float)d", changes)
+	}
+
+	if _, err := a.AnalyzeFile(filepath.Join(dir, "missing.c")); err == nil {
+		t.Errorf("AnalyzeFile of a missing file succeeded")
+	}
+	if _, err := New(Options{MaxVisits: 5}).AnalyzeFile(oldPath); err != ErrTruncated {
+		t.Errorf("AnalyzeFile within 5 visits returned error %v, want ErrTruncated", err)
+	}
+}
+
+func TestAnalyzeTree(t *testing.T) {
+	dir, err := ioutil.TempDir("", "castdiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	write := func(name, src string) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(src), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("a.c", "float f(double d) { return (float)d; }\n")
+	write("sub/b.h", "static int g(long n) { return (int)n; }\n")
+	write("vendor/c.c", "int h(double d) { return (int)d; }\n")
+	write("notes.txt", "(int)x\n")
+
+	infos, err := AnalyzeTree(dir, Options{Exclude: []string{"vendor"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, info := range infos {
+		got = append(got, info.Span.Start.File+":"+info.Func)
+	}
+	if want := "a.c:f " + filepath.Join("sub", "b.h") + ":g"; strings.Join(got, " ") != want {
+		t.Errorf("AnalyzeTree found casts at %q, want %q", got, want)
+	}
+	if infos[0].Category != FloatNarrowing {
+		t.Errorf("cast in a.c has category %s, want %s", infos[0].Category, FloatNarrowing)
+	}
+}
@@ -0,0 +1,157 @@
+package cc
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A CastInfo describes a single explicit cast found in a program.
+type CastInfo struct {
+	Expr       *Expr        `json:"-"`                    // the Cast expression
+	Span       Span         `json:"span"`                 // location of the cast
+	Func       string       `json:"func"`                 // name of the enclosing function, "" outside functions
+	From       string       `json:"from"`                 // spelling of the operand type, "" if unknown
+	To         string       `json:"to"`                   // spelling of the target type
+	Element    string       `json:"element,omitempty"`    // designated initializer element set by the cast, such as "[100]"
+	Category   CastCategory `json:"category,omitempty"`   // set by ClassifyCasts
+	Suggestion string       `json:"suggestion,omitempty"` // pattern that avoids the cast, set by some rules
+	Count      int          `json:"count,omitempty"`      // number of identical findings merged by Coalesce
+	Ordinal    int          `json:"ordinal"`              // 1 for the first cast in Func from From to To, 2 for the second, and so on
+}
+
+// Operand returns the source text of the expression being cast.
+func (i CastInfo) Operand() string {
+	if i.Expr == nil || i.Expr.Left == nil {
+		return ""
+	}
+	return i.Expr.Left.String()
+}
+
+// Fingerprint identifies a finding independently of where it appears in
+// the file, so that it survives edits elsewhere. It combines
+//
+//   - the name of the enclosing function,
+//   - the category,
+//   - the spellings of the source and target types, and
+//   - the ordinal of the cast among those in the function with the same
+//     source and target types.
+//
+// Lines, columns and the operand text are deliberately left out: moving
+// a function, reformatting it or renaming a variable keeps the
+// fingerprints of its findings, while adding a cast of the same shape
+// earlier in the function shifts the ordinals of those after it.
+func (i CastInfo) Fingerprint() string {
+	return strings.Join([]string{i.Func, string(i.Category), i.From, i.To, strconv.Itoa(i.Ordinal)}, "|")
+}
+
+// Spelling returns the C spelling of t as it would appear in a cast,
+// for example "unsigned char*".
+func (t *Type) Spelling() string {
+	if t == nil {
+		return ""
+	}
+	var p Printer
+	p.hideComments = true
+	p.printType(t, "")
+	return p.String()
+}
+
+// Casts returns the explicit casts in x, in source order.
+func Casts(x Syntax) []CastInfo {
+	var casts []CastInfo
+	walkCasts(x, nil, 0, func(info CastInfo, stack []Syntax) {
+		casts = append(casts, info)
+	})
+	return casts
+}
+
+// isCast reports whether x is an explicit cast: a C cast or one of the
+// C++ named casts.
+func (x *Expr) isCast() bool {
+	return x.Op == Cast || x.Op == CxxCast
+}
+
+// walkCasts calls f for each explicit cast in x, in source order,
+// passing the syntax enclosing the cast, innermost last.
+// Designator indexes are evaluated using env, and ordinals are counted
+// from the start of x. If max is positive, walkCasts gives up after
+// visiting max nodes and reports that it stopped early.
+func walkCasts(x Syntax, env *Env, max int, f func(info CastInfo, stack []Syntax)) (truncated bool) {
+	var stack []Syntax
+	ordinals := map[string]int{}
+	visits := 0
+	defer func() {
+		if e := recover(); e != nil {
+			if e != errBudget {
+				panic(e)
+			}
+			truncated = true
+		}
+	}()
+	Walk(x, func(x Syntax) {
+		if visits++; max > 0 && visits > max {
+			panic(errBudget) // Walk cannot be stopped otherwise
+		}
+		if x, ok := x.(*Expr); ok && x.isCast() {
+			info := CastInfo{
+				Expr: x,
+				Span: x.Span,
+				From: x.Left.TypeOf().Spelling(),
+				To:   x.Type.Spelling(),
+			}
+			if d := EnclosingFunction(stack); d != nil {
+				info.Func = d.Name.String()
+			}
+			for _, s := range stack {
+				if init, ok := s.(*Init); ok {
+					info.Element += designator(init.Prefix, env)
+				}
+			}
+			shape := info.Func + "\x00" + info.From + "\x00" + info.To
+			ordinals[shape]++
+			info.Ordinal = ordinals[shape]
+			f(info, stack)
+		}
+		stack = append(stack, x)
+	}, func(x Syntax) {
+		stack = stack[:len(stack)-1]
+	})
+	return false
+}
+
+// errBudget unwinds walkCasts when its visit budget is used up.
+var errBudget = errors.New("visit budget exceeded")
+
+// EnclosingFunction returns the innermost function definition in stack,
+// the syntax enclosing a node with the innermost last, or nil if the
+// node is not inside a function body.
+func EnclosingFunction(stack []Syntax) *Decl {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if d, ok := stack[i].(*Decl); ok && d.Body != nil {
+			return d
+		}
+	}
+	return nil
+}
+
+// designator formats the designators of an initializer element,
+// with array indexes evaluated where possible: [N+1] = becomes "[5]"
+// if N is the constant 4.
+func designator(prefixes []*Prefix, env *Env) string {
+	var s string
+	for _, p := range prefixes {
+		switch {
+		case p.Dot != nil:
+			s += "." + p.Dot.String()
+		case p.Index != nil:
+			if v, ok := p.Index.ConstValue(env); ok {
+				s += fmt.Sprintf("[%d]", v)
+			} else {
+				s += "[" + p.Index.String() + "]"
+			}
+		}
+	}
+	return s
+}
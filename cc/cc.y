@@ -81,6 +81,7 @@ func nextId() int {
 	tc typeClass
 	tk TypeKind
 	typ *Type
+	typs []*Type
 	symlit *SymbolLiteral
 	boollit *BooleanLiteral
 	reallit *RealLiteral
@@ -148,6 +149,11 @@ func nextId() int {
 %token	<str>	tokGlobal
 %token	<str>	tokShared
 %token	<str>	tokRestrict
+%token	<str>	tokBuiltin
+%token	<str>	tokClass
+%token	<str>	tokAtomic
+%token	<str>	tokThreadLocal
+%token	<str>	tokCxxCast
 
 %type	<abdecor>	abdecor abdec1
 %type	<decl>	fnarg fndef edecl
@@ -178,7 +184,8 @@ func nextId() int {
 %type	<syntaxs>	string_list
 %type	<tc>	typeclass
 %type	<tk>	structunion
-%type	<typ>	abtype type typespec
+%type	<typ>	abtype type typespec base enum_base enum_base_opt
+%type	<typs>	abtype_list base_list base_clause_opt
 
 // fake operators to resolve if/else ambiguity
 %left	tokShift
@@ -235,6 +242,7 @@ prog:
 	{
 		$<span>$ = span($<span>1, $<span>2)
 		$$ = append($1, $2...)
+		yylex.(*lexer).timeDecls($2)
 	}
 |	prog tokAUTOLIB '(' tokName ')'
 	{
@@ -555,6 +563,34 @@ expr:
 		$<span>$ = span($<span>1, $<span>6)
 		$$ = &Expr{SyntaxInfo: SyntaxInfo{Span: $<span>$}, Id: nextId(), Op: VaArg, Left: $3, Type: $5}
 	}
+|	tokBuiltin '(' expr_list_opt ')'
+	{
+		$<span>$ = span($<span>1, $<span>4)
+		$$ = &Expr{SyntaxInfo: SyntaxInfo{Span: $<span>$}, Id: nextId(), Op: Builtin, List: $3,
+			Text: &SymbolLiteral{Value: $1, Id: nextId(), SyntaxInfo: SyntaxInfo{Span: $<span>1}},
+		}
+	}
+|	tokBuiltin '(' abtype_list ')'
+	{
+		$<span>$ = span($<span>1, $<span>4)
+		$$ = &Expr{SyntaxInfo: SyntaxInfo{Span: $<span>$}, Id: nextId(), Op: Builtin, TypeArgs: $3,
+			Text: &SymbolLiteral{Value: $1, Id: nextId(), SyntaxInfo: SyntaxInfo{Span: $<span>1}},
+		}
+	}
+|	tokBuiltin '(' abtype_list ',' expr_list ')'
+	{
+		$<span>$ = span($<span>1, $<span>6)
+		$$ = &Expr{SyntaxInfo: SyntaxInfo{Span: $<span>$}, Id: nextId(), Op: Builtin, TypeArgs: $3, List: $5,
+			Text: &SymbolLiteral{Value: $1, Id: nextId(), SyntaxInfo: SyntaxInfo{Span: $<span>1}},
+		}
+	}
+|	tokCxxCast '<' abtype '>' '(' expr ')'
+	{
+		$<span>$ = span($<span>1, $<span>7)
+		$$ = &Expr{SyntaxInfo: SyntaxInfo{Span: $<span>$}, Id: nextId(), Op: CxxCast, Type: $3, Left: $6,
+			Text: &SymbolLiteral{Value: $1, Id: nextId(), SyntaxInfo: SyntaxInfo{Span: $<span>1}},
+		}
+	}
 
 block1:
 	{
@@ -766,6 +802,16 @@ abdec1:
 		}
 
 	}
+|	abdecor '[' tokStatic expr ']'
+	{
+		$<span>$ = span($<span>1, $<span>5)
+		abdecor := $1
+		span := $<span>$
+		expr := $4
+		$$ = func(t *Type) *Type {
+			return abdecor(&Type{SyntaxInfo: SyntaxInfo{Span: span}, Kind: Array, Base: t, Width: expr, Static: true, Id: nextId()})
+		}
+	}
 |	'(' abdecor ')'
 	{
 		$<span>$ = span($<span>1, $<span>3)
@@ -815,6 +861,16 @@ decor:
 			return decor(&Type{SyntaxInfo: SyntaxInfo{Span: span}, Kind: Array, Base: t, Width: expr, Id: nextId()})
 		}
 	}
+|	decor '[' tokStatic expr ']'
+	{
+		$<span>$ = span($<span>1, $<span>5)
+		decor := $1
+		span := $<span>$
+		expr := $4
+		$$ = func(t *Type) (*Type, Syntax) {
+			return decor(&Type{SyntaxInfo: SyntaxInfo{Span: span}, Kind: Array, Base: t, Width: expr, Static: true, Id: nextId()})
+		}
+	}
 
 // Function argument
 fnarg:
@@ -925,6 +981,15 @@ cname:
 			SyntaxInfo: SyntaxInfo{Span: $<span>$},
 		}
 	}
+|	tokThreadLocal
+	{
+		$<span>$ = $<span>1
+		$$ = &LanguageKeyword{
+			Value: $1,
+			Id: nextId(), 
+			SyntaxInfo: SyntaxInfo{Span: $<span>$},
+		}
+	}
 
 // Qualifier words
 qname:
@@ -946,6 +1011,15 @@ qname:
 			SyntaxInfo: SyntaxInfo{Span: $<span>$},
 		}
 	}
+|	tokAtomic %prec tokShift
+	{
+		$<span>$ = $<span>1
+		$$ = &LanguageKeyword{
+			Value: $1,
+			Id: nextId(), 
+			SyntaxInfo: SyntaxInfo{Span: $<span>$},
+		}
+	}
 | tokDevice
   {
 		$<span>$ = $<span>1
@@ -1118,6 +1192,11 @@ typespec:
 			}
 		}
 	}
+|	tokAtomic '(' abtype ')'
+	{
+		$<span>$ = span($<span>1, $<span>4)
+		$$ = qualify($3, Atomic)
+	}
 
 // Types annotated with class info.
 //	typeclass:
@@ -1173,10 +1252,10 @@ type:
 		if $1.c != 0 {
 			yylex.(*lexer).Errorf("%v not allowed here", $1.c)
 		}
-		if $1.q != 0 && $1.q != Const && $1.q != Volatile {
+		if $1.q&^(Const|Volatile|Atomic) != 0 {
 			yylex.(*lexer).Errorf("%v ignored here (TODO)?", $1.q)
 		}
-		$$ = $1.t
+		$$ = qualify($1.t, $1.q)
 	}
 
 abtype:
@@ -1192,10 +1271,9 @@ decl:
 	{
 		lx := yylex.(*lexer)
 		$<span>$ = span($<span>1, $<span>3)
-		// TODO: use $1.q
 		$$ = nil
 		for _, idec := range $2 {
-			typ, name := idec.d($1.t)
+			typ, name := idec.d(qualify($1.t, $1.q))
 			d := &Decl{
 				SyntaxInfo: SyntaxInfo{Span: $<span>$},
 				Name: name,
@@ -1211,7 +1289,7 @@ decl:
 			d := &Decl{
 				SyntaxInfo: SyntaxInfo{Span: $<span>$},
 				Name: &SymbolLiteral{},
-				Type: $1.t,
+				Type: qualify($1.t, $1.q),
 				Storage: $1.c,
 				Id: nextId(),
 			}
@@ -1225,10 +1303,9 @@ topdecl:
 	{
 		lx := yylex.(*lexer)
 		$<span>$ = span($<span>1, $<span>3)
-		// TODO: use $1.q
 		$$ = nil
 		for _, idec := range $2 {
-			typ, name := idec.d($1.t)
+			typ, name := idec.d(qualify($1.t, $1.q))
 			d := lx.lookupDecl(name)
 			if d == nil {
 				d = &Decl{
@@ -1252,7 +1329,7 @@ topdecl:
 			d := &Decl{
 				SyntaxInfo: SyntaxInfo{Span: $<span>$},
 				Name: &SymbolLiteral{},
-				Type: $1.t,
+				Type: qualify($1.t, $1.q),
 				Storage: $1.c,
 				Id: nextId(),
 			}
@@ -1281,7 +1358,7 @@ fndef:
 	typeclass decor decl_list_opt
 	{
 		lx := yylex.(*lexer)
-		typ, name := $2($1.t)
+		typ, name := $2(qualify($1.t, $1.q))
 		if typ.Kind != Func {
 			yylex.(*lexer).Errorf("invalid function definition")
 			return 0
@@ -1362,7 +1439,17 @@ sudecor:
 	}
 
 sudecl:
-	type sudecor_list_opt ';'
+	tokName ':'
+	{
+		$<span>$ = span($<span>1, $<span>2)
+		switch $1 {
+		case "public", "protected", "private":
+		default:
+			yylex.(*lexer).Errorf("unexpected %s in struct", $1)
+		}
+		$$ = nil
+	}
+|	type sudecor_list_opt ';'
 	{
 		$<span>$ = span($<span>1, $<span>3)
 		$$ = nil
@@ -1385,7 +1472,7 @@ sudecl:
 	}
 
 typespec:
-	structunion tag
+	structunion tag %prec tokShift
 	{
 		$<span>$ = span($<span>1, $<span>2)
 		$$ = yylex.(*lexer).pushType(&Type{
@@ -1406,6 +1493,80 @@ typespec:
 			Id: nextId(),
 		})
 	}
+|	structunion tag ':' base_list '{' sudecl_list '}'
+	{
+		$<span>$ = span($<span>1, $<span>7)
+		$$ = yylex.(*lexer).pushClass(&Type{
+			SyntaxInfo: SyntaxInfo{Span: $<span>$},
+			Kind: $1,
+			Tag: $2,
+			Decls: $6,
+			Bases: $4,
+			Id: nextId(),
+		})
+	}
+|	tokClass tag %prec tokShift
+	{
+		$<span>$ = span($<span>1, $<span>2)
+		$$ = yylex.(*lexer).pushClass(&Type{
+			SyntaxInfo: SyntaxInfo{Span: $<span>$},
+			Kind: Struct,
+			Tag: $2,
+			Id: nextId(),
+		})
+	}
+|	tokClass tag base_clause_opt '{' sudecl_list '}'
+	{
+		$<span>$ = span($<span>1, $<span>6)
+		$$ = yylex.(*lexer).pushClass(&Type{
+			SyntaxInfo: SyntaxInfo{Span: $<span>$},
+			Kind: Struct,
+			Tag: $2,
+			Decls: $5,
+			Bases: $3,
+			Id: nextId(),
+		})
+	}
+
+// C++ base classes
+base_clause_opt:
+	{
+		$$ = nil
+	}
+|	':' base_list
+	{
+		$<span>$ = span($<span>1, $<span>2)
+		$$ = $2
+	}
+
+base_list:
+	base
+	{
+		$<span>$ = $<span>1
+		$$ = []*Type{$1}
+	}
+|	base_list ',' base
+	{
+		$<span>$ = span($<span>1, $<span>3)
+		$$ = append($1, $3)
+	}
+
+base:
+	tag
+	{
+		$<span>$ = $<span>1
+		$$ = yylex.(*lexer).lookupBase($1)
+	}
+|	tokName tag
+	{
+		$<span>$ = span($<span>1, $<span>2)
+		switch $1 {
+		case "public", "protected", "private", "virtual":
+		default:
+			yylex.(*lexer).Errorf("unexpected %s in base class list", $1)
+		}
+		$$ = yylex.(*lexer).lookupBase($2)
+	}
 
 initprefix:
 	'.' tag
@@ -1428,7 +1589,7 @@ expr:
 
 // enum
 typespec:
-	tokEnum tag
+	tokEnum tag %prec tokShift
 	{
 		$<span>$ = span($<span>1, $<span>2)
 		$$ = yylex.(*lexer).pushType(&Type{SyntaxInfo: SyntaxInfo{Span: $<span>$}, Kind: Enum, Tag: $2, Id: nextId()})
@@ -1438,6 +1599,44 @@ typespec:
 		$<span>$ = span($<span>1, $<span>6)
 		$$ = yylex.(*lexer).pushType(&Type{SyntaxInfo: SyntaxInfo{Span: $<span>$}, Kind: Enum, Tag: $2, Decls: $4, Id: nextId()})
 	}
+|	tokEnum tag enum_base '{' edecl_list comma_opt '}'
+	{
+		$<span>$ = span($<span>1, $<span>7)
+		$$ = yylex.(*lexer).pushType(&Type{SyntaxInfo: SyntaxInfo{Span: $<span>$}, Kind: Enum, Tag: $2, Base: $3, Decls: $5, Id: nextId()})
+	}
+|	tokEnum tokClass tag %prec tokShift
+	{
+		$<span>$ = span($<span>1, $<span>3)
+		$$ = yylex.(*lexer).pushType(&Type{SyntaxInfo: SyntaxInfo{Span: $<span>$}, Kind: Enum, Tag: $3, Id: nextId()})
+	}
+|	tokEnum tokClass tag enum_base_opt '{' edecl_list comma_opt '}'
+	{
+		$<span>$ = span($<span>1, $<span>8)
+		$$ = yylex.(*lexer).pushType(&Type{SyntaxInfo: SyntaxInfo{Span: $<span>$}, Kind: Enum, Tag: $3, Base: $4, Decls: $6, Id: nextId()})
+	}
+
+// C++11 enum underlying type
+enum_base_opt:
+	{
+		$$ = nil
+	}
+|	enum_base
+	{
+		$<span>$ = $<span>1
+		$$ = $1
+	}
+
+enum_base:
+	':' tokTypeName
+	{
+		$<span>$ = span($<span>1, $<span>2)
+		$$ = $<typ>2
+	}
+|	':' cqtname_list
+	{
+		$<span>$ = span($<span>1, $<span>2)
+		_, _, $$ = splitTypeWords($2)
+	}
 
 edecl:
 	tokName eqexpr_opt
@@ -1606,6 +1805,18 @@ expr_list:
 		$$ = append($1, $3)
 	}
 
+abtype_list:
+	abtype
+	{
+		$<span>$ = $<span>1
+		$$ = []*Type{$1}
+	}
+|	abtype_list ',' abtype
+	{
+		$<span>$ = span($<span>1, $<span>3)
+		$$ = append($1, $3)
+	}
+
 expr_list_opt:
 	{
 		$<span>$ = Span{}
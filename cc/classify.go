@@ -0,0 +1,1217 @@
+package cc
+
+import "math/bits"
+
+// A CastCategory names a kind of cast reported by ClassifyCasts.
+type CastCategory string
+
+const (
+	PlainCast                CastCategory = "PlainCast"                // cast matched by no rule
+	FloatNarrowing           CastCategory = "FloatNarrowing"           // floating cast to lower precision
+	SizeofTruncation         CastCategory = "SizeofTruncation"         // sizeof result cast narrower than size_t
+	ByteArithmeticCast       CastCategory = "ByteArithmeticCast"       // byte pointer cast used in pointer arithmetic
+	VectorReinterpretCast    CastCategory = "VectorReinterpretCast"    // cast between differently shaped vector types
+	ImplicitDeclCast         CastCategory = "ImplicitDeclCast"         // cast of the result of an undeclared function
+	UncheckedDowncast        CastCategory = "UncheckedDowncast"        // base to derived class pointer cast without a runtime check
+	ArrayDecayCast           CastCategory = "ArrayDecayCast"           // decayed array cast to a pointer to another element type
+	EndianAssumptionCast     CastCategory = "EndianAssumptionCast"     // byte pointer cast indexed at a constant offset
+	MaskTruncationCast       CastCategory = "MaskTruncationCast"       // masked value cast narrower than the mask
+	InductionVarCast         CastCategory = "InductionVarCast"         // cast of the counter of an enclosing for loop
+	GenericPointerCast       CastCategory = "GenericPointerCast"       // C cast of a void* returned by an allocator or container
+	SizeofOperandCast        CastCategory = "SizeofOperandCast"        // cast as the operand of sizeof
+	HandleTypeConfusion      CastCategory = "HandleTypeConfusion"      // cast between two distinct handle types
+	TernaryCondCast          CastCategory = "TernaryCondCast"          // cast in the condition of a ?: expression
+	ShiftSignCast            CastCategory = "ShiftSignCast"            // signedness-changing cast of the value shifted right
+	EnumToBoolCast           CastCategory = "EnumToBoolCast"           // cast of an enumeration value to bool
+	UndersizedBufferCast     CastCategory = "UndersizedBufferCast"     // buffer cast to a larger struct that is later copied in full
+	CharIndexCast            CastCategory = "CharIndexCast"            // plain char widened to an array index
+	EnumUnderlyingNarrowing  CastCategory = "EnumUnderlyingNarrowing"  // cast to an enum of a value wider than its underlying type
+	FunctionCastCall         CastCategory = "FunctionCastCall"         // call through a cast to a different function type
+	StringizedCast           CastCategory = "StringizedCast"           // cast in a macro argument used with # or ##
+	SmartPointerEscapeCast   CastCategory = "SmartPointerEscapeCast"   // cast of the raw pointer taken out of a smart pointer
+	ArrayParamSizeCast       CastCategory = "ArrayParamSizeCast"       // cast argument smaller than its [static N] parameter
+	LoopStrideCast           CastCategory = "LoopStrideCast"           // pointer cast to another element size indexed inside a loop
+	FloatBitPunCast          CastCategory = "FloatBitPunCast"          // float read as an integer, or the reverse, through a pointer cast
+	IndexArithCast           CastCategory = "IndexArithCast"           // array index computed in one width and cast to another
+	NestedConstCast          CastCategory = "NestedConstCast"          // pointer cast dropping const below the first level of indirection
+	OverflowSemanticCast     CastCategory = "OverflowSemanticCast"     // signedness-changing cast of arithmetic that may overflow
+	VolatileRoundTripCast    CastCategory = "VolatileRoundTripCast"    // cast chain adding then removing volatile, or the reverse
+	FixedWidthNarrowing      CastCategory = "FixedWidthNarrowing"      // cast from a fixed-width integer type to a narrower one
+	TwosComplementAssumption CastCategory = "TwosComplementAssumption" // signed to unsigned and back round trip; opt-in
+	WideningIndexCast        CastCategory = "WideningIndexCast"        // pointer cast to larger elements indexed directly
+	CommaCast                CastCategory = "CommaCast"                // cast of a comma expression with side effects before its value
+	BoolToPointerCast        CastCategory = "BoolToPointerCast"        // cast of a bool to a pointer
+	LValueCast               CastCategory = "LValueCast"               // cast in the object written by an assignment
+	FormatMismatchCast       CastCategory = "FormatMismatchCast"       // printf argument cast to a type its conversion does not expect
+	CastOnChangedType        CastCategory = "CastOnChangedType"        // changed cast to a type whose definition changed too; reported by Diff
+)
+
+// A castRule reports the casts belonging to one category.
+// A rule that knows a better pattern than the cast suggests it
+// by returning a non-empty suggestion from suggest.
+type castRule struct {
+	category CastCategory
+	match    func(c *castContext) bool
+	suggest  func(c *castContext) string
+}
+
+var castRules = []castRule{
+	{FloatNarrowing, func(c *castContext) bool { return c.x.LosesFloatingPrecision() }, nil},
+	{SizeofTruncation, truncatesSizeof, nil},
+	{ByteArithmeticCast, byteArithmetic, nil},
+	{VectorReinterpretCast, reinterpretsVector, nil},
+	{ImplicitDeclCast, callsUndeclared, nil},
+	{UncheckedDowncast, func(c *castContext) bool { return c.x.IsUncheckedDowncast(c.env) }, nil},
+	{ArrayDecayCast, changesDecayedElem, nil},
+	{EndianAssumptionCast, indexesBytes, nil},
+	{MaskTruncationCast, truncatesMask, nil},
+	{InductionVarCast, castsInductionVar, nil},
+	{GenericPointerCast, castsGenericPointer, suggestTypedHelper},
+	{SizeofOperandCast, measuresCast, nil},
+	{HandleTypeConfusion, confusesHandles, nil},
+	{TernaryCondCast, inTernaryCond, nil},
+	{ShiftSignCast, shiftsSignChange, nil},
+	{EnumToBoolCast, enumToBool, nil},
+	{UndersizedBufferCast, undersizedBuffer, nil},
+	{CharIndexCast, indexesByChar, suggestUnsignedChar},
+	{EnumUnderlyingNarrowing, narrowsToEnum, nil},
+	{FunctionCastCall, callsThroughCast, nil},
+	{StringizedCast, stringized, nil},
+	{SmartPointerEscapeCast, escapesSmartPointer, nil},
+	{ArrayParamSizeCast, undersizedArrayArg, nil},
+	{LoopStrideCast, changesLoopStride, nil},
+	{FloatBitPunCast, punsFloatBits, suggestBitCopy},
+	{IndexArithCast, castsIndexArith, nil},
+	{NestedConstCast, func(c *castContext) bool { return constDropDepth(c.x.Left.TypeOf(), c.x.Type) > 1 }, nil},
+	{OverflowSemanticCast, changesOverflowSemantics, nil},
+	{VolatileRoundTripCast, roundTripsVolatile, nil},
+	{FixedWidthNarrowing, func(c *castContext) bool { return c.x.FixedWidthDelta(c.env) > 0 }, nil},
+	{TwosComplementAssumption, assumesTwosComplement, nil},
+	{WideningIndexCast, widensIndex, nil},
+	{CommaCast, castsComma, nil},
+	{BoolToPointerCast, boolToPointer, nil},
+	{LValueCast, castsLValue, nil},
+	{FormatMismatchCast, mismatchesFormat, nil},
+}
+
+// optInCategories are the categories reported only when named in
+// Env.Enable, because what they flag is almost always intended.
+var optInCategories = map[CastCategory]bool{
+	TwosComplementAssumption: true,
+}
+
+// narrowingCategories are the categories of casts that lose range or
+// precision, which Env.AllowNarrowing suppresses.
+var narrowingCategories = map[CastCategory]bool{
+	FloatNarrowing:          true,
+	SizeofTruncation:        true,
+	MaskTruncationCast:      true,
+	EnumUnderlyingNarrowing: true,
+	FixedWidthNarrowing:     true,
+}
+
+// A castContext is a cast being classified together with its surroundings.
+type castContext struct {
+	env   *Env
+	x     *Expr    // the cast
+	stack []Syntax // syntax enclosing x, innermost last
+}
+
+// parent returns the expression directly using the value of the cast,
+// looking through parentheses, or nil if there is none.
+func (c *castContext) parent() *Expr {
+	for i := len(c.stack) - 1; i >= 0; i-- {
+		x, ok := c.stack[i].(*Expr)
+		if !ok {
+			return nil
+		}
+		if x.Op != Paren {
+			return x
+		}
+	}
+	return nil
+}
+
+// ClassifyCasts returns a finding for every cast in x, using env for
+// program context. A cast matched by several rules is reported once per
+// category; a cast matched by none is reported as a PlainCast.
+func ClassifyCasts(x Syntax, env *Env) []CastInfo {
+	infos, _ := ClassifyCastsLimited(x, env, ClassifyOptions{})
+	return infos
+}
+
+// ClassifyOptions bounds the work done by ClassifyCastsLimited.
+type ClassifyOptions struct {
+	// MaxVisits, if positive, is the number of syntax nodes that may be
+	// visited looking for casts. Classification stops when it is used
+	// up, so that pathological inputs cannot hang interactive tools.
+	MaxVisits int
+}
+
+// ClassifyCastsLimited is like ClassifyCasts but stops once the budget
+// in opts is used up. If truncated is set, the findings are those of the
+// casts visited so far and may be incomplete.
+func ClassifyCastsLimited(x Syntax, env *Env, opts ClassifyOptions) (infos []CastInfo, truncated bool) {
+	truncated = walkCasts(x, env, opts.MaxVisits, func(info CastInfo, stack []Syntax) {
+		c := &castContext{env: env, x: info.Expr, stack: stack}
+		matched := false
+		allowed := env.allowsNarrowing(EnclosingFunction(stack))
+		for _, r := range castRules {
+			if allowed && narrowingCategories[r.category] || !env.enabled(r.category) {
+				continue
+			}
+			if r.match(c) {
+				info.Category = r.category
+				info.Suggestion = ""
+				if r.suggest != nil {
+					info.Suggestion = r.suggest(c)
+				}
+				infos = append(infos, info)
+				matched = true
+			}
+		}
+		if !matched {
+			info.Category = PlainCast
+			infos = append(infos, info)
+		}
+	})
+	return infos, truncated
+}
+
+// LosesFloatingPrecision reports whether x is a cast from a floating
+// type to a floating type of lower precision, such as (float)d for a
+// double d or (double)ld for a long double ld.
+func (x *Expr) LosesFloatingPrecision() bool {
+	if !x.isCast() {
+		return false
+	}
+	to := x.Type.FloatRank()
+	return to > 0 && x.Left.TypeOf().FloatRank() > to
+}
+
+// ChangesSign reports whether x is a cast between a signed and an
+// unsigned integer type, such as (unsigned)i for an int i.
+func (x *Expr) ChangesSign() bool {
+	if !x.isCast() {
+		return false
+	}
+	from := x.Left.TypeOf()
+	return from.IsInteger() && x.Type.IsInteger() && from.IsUnsigned() != x.Type.IsUnsigned()
+}
+
+// CastsAwayConst reports whether x is a cast between pointer types that
+// drops a const qualifier at any level of indirection, looking through
+// pointers and arrays: (int*)p for a const int *p, but also
+// (int (*)[10])q for a const int (*q)[10], where the const is on the
+// elements of the array pointed to.
+func (x *Expr) CastsAwayConst() bool {
+	return x.isCast() && constDropDepth(x.Left.TypeOf(), x.Type) > 0
+}
+
+// constDropDepth returns the level of indirection, counting pointers and
+// arrays from 1, at which the type to lacks a const qualifier that from
+// has, or 0 if there is none.
+func constDropDepth(from, to *Type) int {
+	for depth := 1; ; depth++ {
+		from, to = elemType(from), elemType(to)
+		if from == nil || to == nil {
+			return 0
+		}
+		if quals(from)&Const != 0 && quals(to)&Const == 0 {
+			return depth
+		}
+	}
+}
+
+// changesOverflowSemantics reports whether the cast changes the
+// signedness of arithmetic, as in (unsigned)(len + extra) for int
+// operands. Overflow of the signed arithmetic is undefined while the
+// unsigned result the cast suggests would wrap, so a bounds check on the
+// result may not mean what it appears to. Casts of a single variable and
+// of constants are not reported.
+func changesOverflowSemantics(c *castContext) bool {
+	if !c.x.ChangesSign() {
+		return false
+	}
+	y := unparen(c.x.Left)
+	switch y.Op {
+	case Add, Sub, Mul:
+	default:
+		return false
+	}
+	_, ok := y.ConstValue(c.env)
+	return !ok
+}
+
+// CastChain returns the casts applied directly to one another starting
+// at x, outermost first, looking through parentheses: for (int)(long)y
+// it returns the casts to int and to long. It returns nil if x is not
+// a cast.
+func (x *Expr) CastChain() []*Expr {
+	var chain []*Expr
+	for y := unparen(x); y != nil && y.isCast(); y = unparen(y.Left) {
+		chain = append(chain, y)
+	}
+	return chain
+}
+
+// roundTripsVolatile reports whether the cast undoes a cast directly
+// beneath it that added or removed volatile, at the same level of
+// indirection, as in (int)(volatile int)x or (int*)(volatile int*)p.
+// Whatever the inner cast was meant to force, the outer one hides it.
+// Only the outermost cast of such a pair is reported.
+func roundTripsVolatile(c *castContext) bool {
+	chain := c.x.CastChain()
+	if len(chain) < 2 {
+		return false
+	}
+	orig, mid, outer := chain[1].Left.TypeOf(), chain[1].Type, c.x.Type
+	for orig != nil && mid != nil && outer != nil {
+		o, m, t := quals(orig)&Volatile, quals(mid)&Volatile, quals(outer)&Volatile
+		if o == t && m != o {
+			return true
+		}
+		orig, mid, outer = elemType(orig), elemType(mid), elemType(outer)
+	}
+	return false
+}
+
+// assumesTwosComplement reports whether the cast converts back to a
+// signed integer type a signed value that the cast beneath it converted
+// to an unsigned one, as in (int)(unsigned)i, expecting negative values
+// to wrap around and return unchanged. That holds only for two's
+// complement representations, and converting an out-of-range value to a
+// signed type is implementation-defined before C23.
+func assumesTwosComplement(c *castContext) bool {
+	chain := c.x.CastChain()
+	if len(chain) < 2 {
+		return false
+	}
+	return chain[1].ChangesSign() && c.x.ChangesSign() && !c.x.Type.IsUnsigned()
+}
+
+// fixedWidths are the bit widths of the <stdint.h> exact-width types.
+var fixedWidths = map[string]int{
+	"int8_t":   8,
+	"int16_t":  16,
+	"int32_t":  32,
+	"int64_t":  64,
+	"uint8_t":  8,
+	"uint16_t": 16,
+	"uint32_t": 32,
+	"uint64_t": 64,
+}
+
+// fixedWidth returns the width of t if it is named through one of the
+// exact-width types, or 0. The width does not depend on the data model.
+func fixedWidth(t *Type, env *Env) int {
+	for t != nil && t.Kind == TypedefType {
+		name := t.Name.String()
+		if n, ok := fixedWidths[name]; ok {
+			return n
+		}
+		// Follow the typedef as declared; t.Base has the names
+		// resolved away.
+		switch {
+		case t.TypeDecl != nil:
+			t = t.TypeDecl.Type
+		case env != nil && env.Typedefs[name] != nil:
+			t = env.Typedefs[name]
+		default:
+			t = t.Base
+		}
+	}
+	return 0
+}
+
+// FixedWidthDelta returns the number of bits lost by x, a cast from one
+// exact-width integer type to a narrower one, as in (int16_t)v for an
+// int32_t v, which loses 16. It returns 0 for any other expression.
+func (x *Expr) FixedWidthDelta(env *Env) int {
+	if !x.isCast() {
+		return 0
+	}
+	from, to := fixedWidth(x.Left.TypeOf(), env), fixedWidth(x.Type, env)
+	if from == 0 || to == 0 || to >= from {
+		return 0
+	}
+	return from - to
+}
+
+// shiftsSignChange reports whether the cast changes the signedness of
+// the left operand of a right shift, as in (unsigned)i >> 2: shifting a
+// negative signed value right is implementation-defined, so the cast
+// changes the result.
+func shiftsSignChange(c *castContext) bool {
+	if !c.x.ChangesSign() {
+		return false
+	}
+	p := c.parent()
+	if p == nil || p.Op != Rsh && p.Op != RshEq {
+		return false
+	}
+	return unparen(p.Left) == c.x
+}
+
+// truncatesSizeof reports whether x casts the result of sizeof to an
+// integer type narrower than size_t.
+func truncatesSizeof(c *castContext) bool {
+	y := unparen(c.x.Left)
+	if y.Op != SizeofExpr && y.Op != SizeofType || !c.x.Type.IsInteger() {
+		return false
+	}
+	m := c.env.DataModel()
+	return m.Sizeof(c.x.Type) < m.SizeT()
+}
+
+// measuresCast reports whether the cast is the operand of sizeof, as in
+// sizeof((int)x). The size measured is that of the target type, whatever
+// the type of x, which is rarely what was meant.
+func measuresCast(c *castContext) bool {
+	p := c.parent()
+	return p != nil && p.Op == SizeofExpr
+}
+
+// inTernaryCond reports whether the cast is part of the condition of a
+// ?: expression, as in (unsigned char)(x & 0x100) ? a : b, where the cast
+// decides which branch is taken.
+func inTernaryCond(c *castContext) bool {
+	child := Syntax(c.x)
+	for i := len(c.stack) - 1; i >= 0; i-- {
+		x, ok := c.stack[i].(*Expr)
+		if !ok {
+			return false
+		}
+		if x.Op == Cond && len(x.List) > 0 && x.List[0] == child {
+			return true
+		}
+		child = x
+	}
+	return false
+}
+
+// confusesHandles reports whether the cast converts a value of one handle
+// type to another, as in (HWND)h for a HANDLE h when both are listed in
+// env.Handles.
+func confusesHandles(c *castContext) bool {
+	from := handleName(c.x.Left.TypeOf(), c.env)
+	to := handleName(c.x.Type, c.env)
+	return from != "" && to != "" && from != to
+}
+
+// handleName returns the first typedef name in the definition of t that
+// env lists as a handle type, or "" if there is none.
+func handleName(t *Type, env *Env) string {
+	if env == nil {
+		return ""
+	}
+	for t != nil && t.Kind == TypedefType {
+		if name := t.Name.String(); env.Handles[name] {
+			return name
+		}
+		// The declared type of a typedef keeps the typedef names it
+		// was written with; t.Base has them resolved away.
+		if u := env.Typedefs[t.Name.String()]; u != nil {
+			t = u
+		} else {
+			t = t.Base
+		}
+	}
+	return ""
+}
+
+// byteArithmetic reports whether the cast converts to a byte pointer
+// whose result is an operand of pointer arithmetic, as in (char*)p + n.
+func byteArithmetic(c *castContext) bool {
+	if !isBytePointer(c.x.Type) {
+		return false
+	}
+	p := c.parent()
+	if p == nil {
+		return false
+	}
+	switch p.Op {
+	case Add, Sub, AddEq, SubEq:
+		return true
+	}
+	return false
+}
+
+// isBytePointer reports whether t is a pointer to a character type.
+func isBytePointer(t *Type) bool {
+	t = resolve(t)
+	if t == nil || t.Kind != Ptr {
+		return false
+	}
+	switch resolve(t.Base).Kind {
+	case Char, Uchar:
+		return true
+	}
+	return false
+}
+
+// unparen returns x with any enclosing parentheses removed.
+func unparen(x *Expr) *Expr {
+	for x != nil && x.Op == Paren {
+		x = x.Left
+	}
+	return x
+}
+
+// DominantCastType returns the type that the function d casts to most
+// often, together with the number of casts targeting it. Typedefs are
+// resolved through env, so casts to a typedef and to the type it names
+// count together. It returns nil, 0 if d contains no casts.
+func (d *Decl) DominantCastType(env *Env) (*Type, int) {
+	var best *Type
+	bestCount := 0
+	count := map[string]int{}
+	seen := map[*Expr]bool{}
+	for _, info := range ClassifyCasts(d, env) {
+		if seen[info.Expr] {
+			continue
+		}
+		seen[info.Expr] = true
+		t := env.Resolve(info.Expr.Type)
+		key := t.Spelling()
+		count[key]++
+		if count[key] > bestCount {
+			best, bestCount = t, count[key]
+		}
+	}
+	return best, bestCount
+}
+
+// reinterpretsVector reports whether the cast converts between vector
+// types, or pointers to them, with different element types or counts,
+// as in (int4*)p for a float4* p.
+func reinterpretsVector(c *castContext) bool {
+	from, to := c.x.Left.TypeOf(), c.x.Type
+	if isPointer(from) && isPointer(to) {
+		from, to = elemType(from), elemType(to)
+	}
+	fe, fn := from.Vector()
+	te, tn := to.Vector()
+	if fe == nil || te == nil {
+		return false
+	}
+	return fe != te || fn != tn
+}
+
+// callsUndeclared reports whether the cast converts the result of a call
+// of a function with no visible declaration, as in (char*)f() where f
+// is implicitly declared to return int.
+func callsUndeclared(c *castContext) bool {
+	y := unparen(c.x.Left)
+	if y.Op != Call {
+		return false
+	}
+	fn := unparen(y.Left)
+	return fn.Op == Name && fn.XDecl == nil
+}
+
+// IsUncheckedDowncast reports whether x converts a pointer to a C++
+// class into a pointer to a class derived from it, using a C-style cast
+// or static_cast, neither of which checks the dynamic type of the object.
+// A dynamic_cast is checked and is never reported.
+func (x *Expr) IsUncheckedDowncast(env *Env) bool {
+	if !x.isCast() || x.Op == CxxCast && x.Text.String() == "dynamic_cast" {
+		return false
+	}
+	from, to := env.Resolve(x.Left.TypeOf()), env.Resolve(x.Type)
+	if from == nil || to == nil || from.Kind != Ptr || to.Kind != Ptr {
+		return false
+	}
+	base, derived := env.Resolve(from.Base), env.Resolve(to.Base)
+	return !sameClass(base, derived) && derivesFrom(derived, base, env)
+}
+
+// derivesFrom reports whether class t has base as a direct or indirect
+// base class.
+func derivesFrom(t, base *Type, env *Env) bool {
+	if t == nil || base == nil || t.Kind != Struct {
+		return false
+	}
+	for _, b := range t.Bases {
+		if b = env.Resolve(b); sameClass(b, base) || derivesFrom(b, base, env) {
+			return true
+		}
+	}
+	return false
+}
+
+// changesDecayedElem reports whether the cast converts an array, decayed
+// to a pointer to its first element, to a pointer to a different element
+// type, as in (int*)buf for a char buf[64]. This changes the stride of
+// any indexing through the result. Casts to void* are not reported.
+func changesDecayedElem(c *castContext) bool {
+	y := unparen(c.x.Left)
+	if y.ImplicitCast() != ArrayToPointerDecay {
+		return false
+	}
+	to := c.env.Resolve(c.x.Type)
+	if to == nil || to.Kind != Ptr {
+		return false
+	}
+	elem, target := c.env.Resolve(elemType(y.TypeOf())), c.env.Resolve(to.Base)
+	if elem == nil || target == nil || target.Kind == Void {
+		return false
+	}
+	return elem.Spelling() != target.Spelling()
+}
+
+// indexesBytes reports whether the cast converts to a byte pointer whose
+// result is indexed at a constant offset, as in ((uint8_t*)&v)[0]. Which
+// byte such an expression reads depends on the byte order of the target.
+func indexesBytes(c *castContext) bool {
+	if !isBytePointer(c.env.Resolve(c.x.Type)) {
+		return false
+	}
+	p := c.parent()
+	if p == nil || p.Op != Index || unparen(p.Left) != c.x {
+		return false
+	}
+	_, ok := p.Right.ConstValue(c.env)
+	return ok
+}
+
+// truncatesMask reports whether the cast converts a value masked with a
+// constant to an integer type too narrow to hold every bit the mask keeps,
+// as in (uint8_t)(x & 0xFFFF).
+func truncatesMask(c *castContext) bool {
+	y := unparen(c.x.Left)
+	if y.Op != And || !c.x.Type.IsInteger() || isBool(c.x.Type, c.env) {
+		return false // conversion to bool tests the whole value
+	}
+	mask, ok := y.Right.ConstValue(c.env)
+	if !ok {
+		mask, ok = y.Left.ConstValue(c.env)
+	}
+	if !ok || mask <= 0 {
+		return false
+	}
+	n := c.env.DataModel().Bits(c.x.Type)
+	return n > 0 && n < bits.Len64(uint64(mask))
+}
+
+// enumToBool reports whether the cast converts an enumeration value to
+// bool, as in (bool)color, where a comparison such as color != Black was
+// probably meant.
+func enumToBool(c *castContext) bool {
+	from := c.env.Resolve(c.x.Left.TypeOf())
+	return from != nil && from.Kind == Enum && isBool(c.x.Type, c.env)
+}
+
+// castsLValue reports whether the cast designates the object written by
+// an assignment, as in *(short*)&val = n or ((struct S*)p)->f = 1. The
+// write goes through a pointer of another type, so it may store more
+// bytes than the object has and corrupt its neighbors.
+func castsLValue(c *castContext) bool {
+	var y Syntax = c.x
+	for i := len(c.stack) - 1; i >= 0; i-- {
+		x, ok := c.stack[i].(*Expr)
+		if !ok {
+			return false
+		}
+		switch x.Op {
+		case Paren, Indir:
+		case Index, Dot, Arrow:
+			if x.Left != y {
+				return false
+			}
+		case Eq, AddEq, SubEq, MulEq, DivEq, ModEq, LshEq, RshEq, AndEq, OrEq, XorEq:
+			return x.Left == y
+		default:
+			return false
+		}
+		y = x
+	}
+	return false
+}
+
+// mismatchesFormat reports whether the cast is an argument of a printf
+// style function and converts to a type that the conversion consuming
+// it does not expect, as in printf("%d", (long)x). The cast was meant
+// to make the argument fit the format, but makes it wrong instead.
+func mismatchesFormat(c *castContext) bool {
+	var arg Syntax = c.x
+	var call *Expr
+	for i := len(c.stack) - 1; i >= 0 && call == nil; i-- {
+		x, ok := c.stack[i].(*Expr)
+		switch {
+		case !ok:
+			return false
+		case x.Op == Paren:
+			arg = x
+		default:
+			call = x
+		}
+	}
+	if call == nil || call.Op != Call || unparen(call.Left).Op != Name {
+		return false
+	}
+	fmtArg, ok := formatArgs[unparen(call.Left).Text.String()]
+	if !ok || fmtArg >= len(call.List) {
+		return false
+	}
+	text, ok := formatText(call.List[fmtArg])
+	if !ok {
+		return false
+	}
+	convs, ok := parseFormat(text)
+	if !ok {
+		return false
+	}
+	for i, x := range call.List[fmtArg+1:] {
+		if x == arg {
+			return i < len(convs) && convs[i].mismatches(c.x.Type, c.env)
+		}
+	}
+	return false
+}
+
+// boolToPointer reports whether the cast converts a bool to a pointer,
+// as in (void*)flag. The result is a null pointer or the address 1,
+// which is never what was meant.
+func boolToPointer(c *castContext) bool {
+	return isPointer(c.x.Type) && isBool(c.x.Left.TypeOf(), c.env)
+}
+
+// isBool reports whether t is _Bool or a typedef of it such as the bool
+// of <stdbool.h>.
+func isBool(t *Type, env *Env) bool {
+	for t != nil && t.Kind == TypedefType {
+		if t.Name.String() == "_Bool" {
+			return true
+		}
+		switch {
+		case t.TypeDecl != nil:
+			t = t.TypeDecl.Type
+		case env != nil && env.Typedefs[t.Name.String()] != nil:
+			t = env.Typedefs[t.Name.String()]
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// castsInductionVar reports whether the cast converts the induction
+// variable of a for loop within the loop body, as in a[(int)i] inside
+// for(i = 0; i < n; i++). The induction variables of a loop are those
+// stepped by its Post expression.
+func castsInductionVar(c *castContext) bool {
+	y := unparen(c.x.Left)
+	if y.Op != Name || y.XDecl == nil {
+		return false
+	}
+	for i := len(c.stack) - 2; i >= 0; i-- {
+		s, ok := c.stack[i].(*Stmt)
+		if !ok || s.Op != For || c.stack[i+1] != s.Body {
+			continue
+		}
+		for _, d := range steppedVars(s.Post) {
+			if d == y.XDecl {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// changesLoopStride reports whether the cast converts a pointer to one
+// whose elements have a different size and the result is indexed or
+// used in pointer arithmetic inside the body of a loop, as in
+//
+//	for (i = 0; i < n; i++)
+//		sum += ((short*)p)[i];
+//
+// The stride of the access differs from that of the pointer, which
+// defeats vectorization and often hides an aliasing violation.
+func changesLoopStride(c *castContext) bool {
+	m := c.env.DataModel()
+	from := c.env.Resolve(elemType(c.x.Left.TypeOf()))
+	to := c.env.Resolve(c.x.Type)
+	if from == nil || to == nil || to.Kind != Ptr {
+		return false
+	}
+	fsize, tsize := m.Sizeof(from), m.Sizeof(c.env.Resolve(to.Base))
+	if fsize == 0 || tsize == 0 || fsize == tsize {
+		return false
+	}
+	p := c.parent()
+	if p == nil {
+		return false
+	}
+	switch p.Op {
+	case Index:
+		if unparen(p.Left) != c.x {
+			return false
+		}
+	case Add, Sub, AddEq, SubEq:
+	default:
+		return false
+	}
+	for i := len(c.stack) - 2; i >= 0; i-- {
+		s, ok := c.stack[i].(*Stmt)
+		if ok && (s.Op == For || s.Op == While || s.Op == Do) && c.stack[i+1] == s.Body {
+			return true
+		}
+	}
+	return false
+}
+
+// StrideFactor returns the ratio of the element size of the pointer
+// type x converts to to that of the pointer it converts from, such as 8
+// for (double*)p with a char *p, or 0 if x is not such a cast or either
+// size is unknown.
+func (x *Expr) StrideFactor(env *Env) float64 {
+	if !x.isCast() || !isPointer(x.Type) || !isPointer(x.Left.TypeOf()) {
+		return 0
+	}
+	m := env.DataModel()
+	from := m.Sizeof(env.Resolve(elemType(x.Left.TypeOf())))
+	to := m.Sizeof(env.Resolve(elemType(x.Type)))
+	if from == 0 || to == 0 {
+		return 0
+	}
+	return float64(to) / float64(from)
+}
+
+// widensIndex reports whether the cast converts a pointer to one whose
+// elements are larger and the result is indexed at once, as in
+// ((double*)bytes)[k]. The index now counts the larger elements, so it
+// reaches StrideFactor times as far into the buffer as it would have.
+func widensIndex(c *castContext) bool {
+	p := c.parent()
+	return p != nil && p.Op == Index && unparen(p.Left) == c.x && c.x.StrideFactor(c.env) > 1
+}
+
+// castsComma reports whether the operand of the cast is a comma
+// expression whose discarded operands have side effects, as in
+// (int)(setup(), value). The cast reads as applying to the whole
+// parenthesized expression, and the call before the comma is easily
+// missed.
+func castsComma(c *castContext) bool {
+	ops := c.x.Left.CommaOperands()
+	for i := 0; i+1 < len(ops); i++ {
+		if ops[i].HasSideEffects() {
+			return true
+		}
+	}
+	return false
+}
+
+// punsFloatBits reports whether the cast converts a pointer to a
+// floating type to a pointer to an integer type, or the reverse, and the
+// result is dereferenced, as in *(uint32_t*)&f. Reading an object
+// through an lvalue of an unrelated type breaks the aliasing rules.
+// Byte pointers may alias anything and are not reported.
+func punsFloatBits(c *castContext) bool {
+	p := c.parent()
+	if p == nil || p.Op != Indir || isBytePointer(c.x.Type) || isBytePointer(c.x.Left.TypeOf()) {
+		return false
+	}
+	from, to := elemType(c.x.Left.TypeOf()), elemType(c.x.Type)
+	if from == nil || to == nil {
+		return false
+	}
+	return from.FloatRank() > 0 && to.IsInteger() || from.IsInteger() && to.FloatRank() > 0
+}
+
+func suggestBitCopy(c *castContext) string {
+	return "copy the bits with memcpy or std::bit_cast"
+}
+
+// castsIndexArith reports whether the cast converts arithmetic to an
+// integer type of a different width and the result is an array index.
+// In a[(long)(i * stride)] for int operands the product overflows int
+// before it is widened; in a[(int)(n * stride)] for long operands the
+// product is truncated. Constant expressions are not reported.
+func castsIndexArith(c *castContext) bool {
+	p := c.parent()
+	if p == nil || p.Op != Index || unparen(p.Right) != c.x && unparen(p.Left) != c.x || isPointer(c.x.TypeOf()) {
+		return false
+	}
+	y := unparen(c.x.Left)
+	switch y.Op {
+	case Add, Sub, Mul, Lsh:
+	default:
+		return false
+	}
+	if !y.TypeOf().IsInteger() || !c.x.Type.IsInteger() {
+		return false
+	}
+	if _, ok := y.ConstValue(c.env); ok {
+		return false
+	}
+	m := c.env.DataModel()
+	return m.Bits(y.TypeOf()) != m.Bits(c.x.Type)
+}
+
+// steppedVars returns the variables incremented, decremented or
+// assigned by the loop step x.
+func steppedVars(x *Expr) []*Decl {
+	if x == nil {
+		return nil
+	}
+	switch x.Op {
+	case Comma:
+		var decls []*Decl
+		for _, y := range x.List {
+			decls = append(decls, steppedVars(y)...)
+		}
+		return decls
+	case Paren:
+		return steppedVars(x.Left)
+	case PreInc, PreDec, PostInc, PostDec, Eq, AddEq, SubEq, MulEq, DivEq, LshEq, RshEq:
+		if y := unparen(x.Left); y.Op == Name && y.XDecl != nil {
+			return []*Decl{y.XDecl}
+		}
+	}
+	return nil
+}
+
+// allocators are the standard functions returning untyped memory.
+var allocators = map[string]bool{
+	"malloc":        true,
+	"calloc":        true,
+	"realloc":       true,
+	"aligned_alloc": true,
+}
+
+// indexesByChar reports whether the cast widens a plain char that is
+// then used as an array index, as in table[(int)ch]. Where char is
+// signed, characters above 0x7f give negative indexes.
+func indexesByChar(c *castContext) bool {
+	if !c.x.Type.IsInteger() || c.x.Type.IsUnsigned() {
+		return false
+	}
+	from := c.env.Resolve(c.x.Left.TypeOf())
+	if from == nil || from.Kind != Char {
+		return false
+	}
+	p := c.parent()
+	if p == nil || p.Op != Index {
+		return false
+	}
+	return unparen(p.Right) == c.x || unparen(p.Left) == c.x
+}
+
+func suggestUnsignedChar(c *castContext) string {
+	return "cast to unsigned char first"
+}
+
+// narrowsToEnum reports whether the cast converts an integer wider than
+// the underlying type of an enumeration to the enumeration, as in (E)n
+// for an int n and enum E : uint8_t. Constants that fit are not reported.
+func narrowsToEnum(c *castContext) bool {
+	to := c.env.Resolve(c.x.Type)
+	if to == nil || to.Kind != Enum || to.Base == nil {
+		return false
+	}
+	from := c.x.Left.TypeOf()
+	if !from.IsInteger() {
+		return false
+	}
+	m := c.env.DataModel()
+	n := m.Bits(to.Base)
+	if n == 0 || m.Bits(from) <= n {
+		return false
+	}
+	if v, ok := c.x.Left.ConstValue(c.env); ok && v >= 0 && bits.Len64(uint64(v)) <= n {
+		return false
+	}
+	return true
+}
+
+// callsThroughCast reports whether the cast converts a function, or a
+// pointer to one, to a pointer to a function of a different type and
+// the result is called, as in ((void(*)(void))f)(). Calling a function
+// through an incompatible type is undefined.
+func callsThroughCast(c *castContext) bool {
+	p := c.parent()
+	if p == nil || p.Op != Call || unparen(p.Left) != c.x {
+		return false
+	}
+	from, to := funcType(c.x.Left.TypeOf()), funcType(c.x.Type)
+	return from != nil && to != nil && !from.Equal(to)
+}
+
+// funcType returns the function type t names or points to, or nil.
+func funcType(t *Type) *Type {
+	t = resolve(t)
+	if t != nil && t.Kind == Ptr {
+		t = resolve(t.Base)
+	}
+	if t == nil || t.Kind != Func {
+		return nil
+	}
+	return t
+}
+
+// stringized reports whether the cast is part of an argument of a
+// function-like macro that applies # or ## to that argument, as in
+//
+//	#define STR(x) #x
+//	STR((int)d)
+//
+// where the cast is spelled into a string or pasted into a token rather
+// than evaluated. Macros are known only through env.
+func stringized(c *castContext) bool {
+	if c.env == nil {
+		return false
+	}
+	var child Syntax = c.x
+	for i := len(c.stack) - 1; i >= 0; i-- {
+		x, ok := c.stack[i].(*Expr)
+		if !ok {
+			return false
+		}
+		if m, ok := macroUse(x, c.env.Macros); ok && x.Op == Call {
+			for k, arg := range x.List {
+				if arg == child && m.Stringizes(k) {
+					return true
+				}
+			}
+		}
+		child = x
+	}
+	return false
+}
+
+// escapesSmartPointer reports whether the cast converts the raw pointer
+// returned by get or release on a smart pointer, as in (Raw*)p.get(),
+// letting the pointer outlive the ownership of the smart pointer.
+func escapesSmartPointer(c *castContext) bool {
+	call := unparen(c.x.Left)
+	if call.Op != Call {
+		return false
+	}
+	m := unparen(call.Left)
+	if m.Op != Dot && m.Op != Arrow {
+		return false
+	}
+	if name := m.Text.String(); name != "get" && name != "release" {
+		return false
+	}
+	if m.Op == Dot {
+		return c.env.isSmartPointer(m.Left.TypeOf())
+	}
+	return c.env.isSmartPointer(elemType(m.Left.TypeOf()))
+}
+
+// undersizedArrayArg reports whether the cast is passed for a parameter
+// declared with a minimum size, as in int a[static 16], and refers to
+// fewer bytes than the parameter requires: either its operand is a
+// smaller array, as in (int*)buf, or it converts to a pointer to a
+// smaller array that is dereferenced, as in *(int(*)[4])p. The cast
+// hides the mismatch from the compiler.
+func undersizedArrayArg(c *castContext) bool {
+	var arg Syntax = c.x
+	var call *Expr
+	deref := false
+	for i := len(c.stack) - 1; i >= 0 && call == nil; i-- {
+		x, ok := c.stack[i].(*Expr)
+		switch {
+		case !ok:
+			return false
+		case x.Op == Paren:
+			arg = x
+		case x.Op == Indir && !deref:
+			arg, deref = x, true
+		default:
+			call = x
+		}
+	}
+	if call == nil || call.Op != Call {
+		return false
+	}
+	fn := funcType(call.Left.TypeOf())
+	if fn == nil {
+		return false
+	}
+	var param *Type
+	for i, x := range call.List {
+		if x == arg && i < len(fn.Decls) {
+			param = resolve(fn.Decls[i].Type)
+		}
+	}
+	if param == nil || param.Kind != Array || !param.Static {
+		return false
+	}
+	n, ok := param.Width.ConstValue(c.env)
+	m := c.env.DataModel()
+	need := n * int64(m.Sizeof(param.Base))
+	if !ok || need <= 0 {
+		return false
+	}
+	have := 0
+	if t := c.env.Resolve(c.x.Left.TypeOf()); t != nil && t.Kind == Array {
+		have = c.env.sizeof(t)
+	} else if t := c.env.Resolve(elemType(c.x.Type)); t != nil && t.Kind == Array {
+		have = c.env.sizeof(t)
+	}
+	return have > 0 && int64(have) < need
+}
+
+// copiers are the functions that write as many bytes as their last
+// argument says.
+var copiers = map[string]bool{
+	"memcpy":  true,
+	"memmove": true,
+	"memset":  true,
+}
+
+// assignedVar returns the variable the value of the cast is stored in,
+// by an assignment such as s = (T*)p or an initialization such as
+// T *s = (T*)p, or nil if there is none.
+func (c *castContext) assignedVar() *Decl {
+	var y Syntax = c.x
+	for i := len(c.stack) - 1; i >= 0; i-- {
+		switch x := c.stack[i].(type) {
+		case *Expr:
+			switch {
+			case x.Op == Paren:
+				y = x
+				continue
+			case x.Op == Eq && x.Right == y:
+				if l := unparen(x.Left); l.Op == Name {
+					return l.XDecl
+				}
+			}
+		case *Init:
+			y = x
+			continue
+		case *Decl:
+			if x.Init == y {
+				return x
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// undersizedBuffer reports whether the cast converts an array to a
+// pointer to a struct larger than the array, and the enclosing function
+// then copies sizeof the struct through the result of the cast, either
+// directly or through the variable it is stored in, as in
+//
+//	char buf[4];
+//	struct S *s = (struct S*)buf;
+//	memcpy(s, src, sizeof(struct S));
+//
+// where the copy writes past the end of buf.
+func undersizedBuffer(c *castContext) bool {
+	t := c.env.Resolve(elemType(c.x.Type))
+	if t == nil || t.Kind != Struct && t.Kind != Union {
+		return false
+	}
+	buf := unparen(c.x.Left)
+	if buf.Op == Addr {
+		buf = unparen(buf.Left)
+	}
+	if buf.Op != Name || buf.XDecl == nil {
+		return false
+	}
+	bt := c.env.Resolve(buf.XDecl.Type)
+	if bt == nil || bt.Kind != Array {
+		return false
+	}
+	m := c.env.DataModel()
+	have, need := m.Sizeof(bt), m.Sizeof(t)
+	if have == 0 || need == 0 || have >= need {
+		return false
+	}
+
+	var body Syntax
+	for i := len(c.stack) - 1; i >= 0; i-- {
+		if d, ok := c.stack[i].(*Decl); ok && d.Body != nil {
+			body = d.Body
+			break
+		}
+	}
+	if body == nil {
+		return false
+	}
+	v := c.assignedVar()
+	copies := false
+	Preorder(body, func(x Syntax) {
+		call, ok := x.(*Expr)
+		if !ok || call.Op != Call || len(call.List) == 0 {
+			return
+		}
+		if fn := unparen(call.Left); fn.Op != Name || !copiers[fn.Text.String()] {
+			return
+		}
+		dst := unparen(call.List[0])
+		if dst != c.x && (v == nil || dst.Op != Name || dst.XDecl != v || call.Span.Start.Byte < c.x.Span.End.Byte) {
+			return
+		}
+		n := unparen(call.List[len(call.List)-1])
+		if n.Op == SizeofType && sameClass(c.env.Resolve(n.Type), t) {
+			copies = true
+		}
+	})
+	return copies
+}
+
+// castsGenericPointer reports whether the C cast converts the void*
+// result of a call, such as (T*)malloc(n) or (T*)list_get(l, i), to a
+// typed pointer.
+func castsGenericPointer(c *castContext) bool {
+	if c.x.Op != Cast || !isPointer(c.x.Type) {
+		return false
+	}
+	call := unparen(c.x.Left)
+	if call.Op != Call {
+		return false
+	}
+	if fn := unparen(call.Left); fn.Op == Name && allocators[fn.Text.String()] {
+		return true
+	}
+	t := resolve(call.TypeOf())
+	return t != nil && t.Kind == Ptr && resolve(t.Base).Kind == Void
+}
+
+func suggestTypedHelper(c *castContext) string {
+	if fn := unparen(unparen(c.x.Left).Left); fn.Op == Name && allocators[fn.Text.String()] {
+		return "use a typed allocator"
+	}
+	return "use a typed accessor"
+}
+
+// sameClass reports whether t and u are the same class, regardless of
+// qualifiers.
+func sameClass(t, u *Type) bool {
+	if t == u {
+		return true
+	}
+	if t == nil || u == nil || t.Tag == nil || u.Tag == nil {
+		return false
+	}
+	return t.Kind == u.Kind && t.Tag.String() != "" && t.Tag.String() == u.Tag.String()
+}
+
+// DropsAtomic reports whether x is a cast that removes the _Atomic
+// qualifier from its operand's type or from a type it points to, as in
+// (int*)p for an _Atomic int *p. Accesses through the result are not
+// atomic.
+func (x *Expr) DropsAtomic() bool {
+	if !x.isCast() {
+		return false
+	}
+	from, to := x.Left.TypeOf(), x.Type
+	for from != nil && to != nil {
+		if quals(from)&Atomic != 0 && quals(to)&Atomic == 0 {
+			return true
+		}
+		from, to = resolve(from), resolve(to)
+		if from.Kind != Ptr || to.Kind != Ptr {
+			break
+		}
+		from, to = from.Base, to.Base
+	}
+	return false
+}
@@ -0,0 +1,944 @@
+package cc
+
+import (
+	"strings"
+	"testing"
+)
+
+// castsIn parses src and returns the classified casts of function f.
+func castsIn(t *testing.T, src string) []CastInfo {
+	var infos []CastInfo
+	for _, info := range ClassifyCasts(mustParse(t, src), nil) {
+		if info.Func == "f" {
+			infos = append(infos, info)
+		}
+	}
+	return infos
+}
+
+// hasCategory reports whether a cast with operand text operand is
+// reported with category cat.
+func hasCategory(infos []CastInfo, operand string, cat CastCategory) bool {
+	for _, info := range infos {
+		if info.Operand() == operand && info.Category == cat {
+			return true
+		}
+	}
+	return false
+}
+
+func TestFloatNarrowing(t *testing.T) {
+	infos := castsIn(t, `
+void f(float fl, double d, long double ld) {
+	fl = (float)d;
+	d = (double)ld;
+	d = (double)fl;
+}`)
+	if len(infos) != 3 {
+		t.Fatalf("found %d casts, want 3", len(infos))
+	}
+	if !infos[0].Expr.LosesFloatingPrecision() || !hasCategory(infos, "d", FloatNarrowing) {
+		t.Errorf("(float)d not reported as %s", FloatNarrowing)
+	}
+	if !hasCategory(infos, "ld", FloatNarrowing) {
+		t.Errorf("(double)ld not reported as %s", FloatNarrowing)
+	}
+	if infos[2].Expr.LosesFloatingPrecision() || !hasCategory(infos, "fl", PlainCast) {
+		t.Errorf("widening (double)fl reported as %s", infos[2].Category)
+	}
+	if infos[0].From != "double" || infos[0].To != "float" {
+		t.Errorf("(float)d converts %q to %q, want double to float", infos[0].From, infos[0].To)
+	}
+}
+
+func TestDominantCastType(t *testing.T) {
+	prog := mustParse(t, `
+typedef long i64;
+long f(int a, int b, int c, double d) {
+	return (long)a + (i64)b + (long)c + (int)d;
+}`)
+	env := BuildEnv(prog, LP64)
+	for _, d := range prog.Decls {
+		if d.Name.String() != "f" {
+			continue
+		}
+		typ, n := d.DominantCastType(env)
+		if typ.Spelling() != "long" || n != 3 {
+			t.Errorf("DominantCastType = %s, %d, want long, 3", typ.Spelling(), n)
+		}
+		return
+	}
+	t.Fatal("function f not found")
+}
+
+func TestSizeofTruncation(t *testing.T) {
+	infos := castsIn(t, `
+typedef unsigned long size_t;
+void f(int n, size_t m) {
+	char arr[16];
+	n = (int)sizeof(arr);
+	m = (size_t)sizeof(arr);
+}`)
+	if !hasCategory(infos, "sizeof (arr)", SizeofTruncation) {
+		t.Errorf("(int)sizeof(arr) not reported as %s", SizeofTruncation)
+	}
+	for _, info := range infos {
+		if info.To == "size_t" && info.Category != PlainCast {
+			t.Errorf("(size_t)sizeof(arr) reported as %s", info.Category)
+		}
+	}
+}
+
+func TestByteArithmeticCast(t *testing.T) {
+	infos := castsIn(t, `
+void f(int *p, long offset) {
+	char *q;
+	q = (char*)p + offset;
+	q = ((unsigned char*)p) - 4;
+	q = (char*)p;
+	offset = (long)p + offset;
+}`)
+	if !hasCategory(infos, "p", ByteArithmeticCast) {
+		t.Errorf("(char*)p + offset not reported as %s", ByteArithmeticCast)
+	}
+	n := 0
+	for _, info := range infos {
+		if info.Category == ByteArithmeticCast {
+			n++
+		}
+	}
+	if n != 2 {
+		t.Errorf("found %d %s casts, want 2", n, ByteArithmeticCast)
+	}
+}
+
+func TestVectorReinterpretCast(t *testing.T) {
+	infos := castsIn(t, `
+#include <vector_types.h>
+void f(float4 *p, float2 *q) {
+	int4 *ip;
+	float4 *fp;
+	ip = (int4*)p;
+	fp = (float4*)q;
+	fp = (float4*)p;
+}`)
+	if len(infos) != 3 {
+		t.Fatalf("found %d casts, want 3", len(infos))
+	}
+	for i, want := range []CastCategory{VectorReinterpretCast, VectorReinterpretCast, PlainCast} {
+		if infos[i].Category != want {
+			t.Errorf("(%s)%s reported as %s, want %s", infos[i].To, infos[i].Operand(), infos[i].Category, want)
+		}
+	}
+}
+
+func TestImplicitDeclCast(t *testing.T) {
+	infos := castsIn(t, `
+char *g(void);
+void f(void) {
+	char *p;
+	p = (char*)undeclared(1);
+	p = (char*)g();
+}`)
+	if len(infos) != 2 {
+		t.Fatalf("found %d casts, want 2", len(infos))
+	}
+	if infos[0].Category != ImplicitDeclCast {
+		t.Errorf("(char*)undeclared(1) reported as %s, want %s", infos[0].Category, ImplicitDeclCast)
+	}
+	if infos[1].Category != PlainCast {
+		t.Errorf("(char*)g() reported as %s, want %s", infos[1].Category, PlainCast)
+	}
+}
+
+func TestDesignatedElement(t *testing.T) {
+	infos := castsIn(t, `
+enum { First, Second, Last = 100 };
+void f(int x) {
+	char buf[128] = { [Last] = (char)x, [Second + 1] = (char)(x+1), 'a' };
+}`)
+	if len(infos) != 2 {
+		t.Fatalf("found %d casts, want 2", len(infos))
+	}
+	for i, want := range []string{"[100]", "[2]"} {
+		if infos[i].Element != want {
+			t.Errorf("cast of %s initializes element %q, want %q", infos[i].Operand(), infos[i].Element, want)
+		}
+	}
+}
+
+func TestUncheckedDowncast(t *testing.T) {
+	infos := castsIn(t, `
+class Base { int x; };
+class Derived : public Base { public: int y; };
+struct More : Derived { int z; };
+void f(Base *basePtr, Derived *d) {
+	Derived *p;
+	More *m;
+	p = static_cast<Derived*>(basePtr);
+	m = (More*)basePtr;
+	p = dynamic_cast<Derived*>(basePtr);
+	basePtr = static_cast<Base*>(d);
+}`)
+	if len(infos) != 4 {
+		t.Fatalf("found %d casts, want 4", len(infos))
+	}
+	for i, want := range []CastCategory{UncheckedDowncast, UncheckedDowncast, PlainCast, PlainCast} {
+		if infos[i].Category != want {
+			t.Errorf("%s reported as %s, want %s", infos[i].Expr, infos[i].Category, want)
+		}
+	}
+	if got := infos[0].Expr.String(); got != "static_cast<Derived*>(basePtr)" {
+		t.Errorf("printed cast = %q, want %q", got, "static_cast<Derived*>(basePtr)")
+	}
+}
+
+func TestArrayDecayCast(t *testing.T) {
+	infos := castsIn(t, `
+void f(void) {
+	char byteBuffer[64];
+	int *ip;
+	char *cp;
+	void *vp;
+	ip = (int*)byteBuffer;
+	cp = (char*)byteBuffer;
+	vp = (void*)byteBuffer;
+}`)
+	if len(infos) != 3 {
+		t.Fatalf("found %d casts, want 3", len(infos))
+	}
+	for i, want := range []CastCategory{ArrayDecayCast, PlainCast, PlainCast} {
+		if infos[i].Category != want {
+			t.Errorf("%s reported as %s, want %s", infos[i].Expr, infos[i].Category, want)
+		}
+	}
+	if k := infos[0].Expr.Left.ImplicitCast(); k != ArrayToPointerDecay {
+		t.Errorf("implicit conversion of byteBuffer = %v, want %v", k, ArrayToPointerDecay)
+	}
+}
+
+func TestEndianAssumptionCast(t *testing.T) {
+	infos := castsIn(t, `
+typedef unsigned char uint8_t;
+void f(unsigned int value, int i) {
+	uint8_t lo, b;
+	lo = ((uint8_t*)&value)[0];
+	b = ((uint8_t*)&value)[i];
+}`)
+	if len(infos) != 2 {
+		t.Fatalf("found %d casts, want 2", len(infos))
+	}
+	if infos[0].Category != EndianAssumptionCast {
+		t.Errorf("%s reported as %s, want %s", infos[0].Expr, infos[0].Category, EndianAssumptionCast)
+	}
+	if infos[1].Category != PlainCast {
+		t.Errorf("%s indexed at a variable offset reported as %s, want %s", infos[1].Expr, infos[1].Category, PlainCast)
+	}
+}
+
+func TestMaskTruncationCast(t *testing.T) {
+	infos := castsIn(t, `
+typedef unsigned char uint8_t;
+void f(unsigned int x) {
+	uint8_t b;
+	unsigned short s;
+	b = (uint8_t)(x & 0xFFFF);
+	b = (uint8_t)(0xFF & x);
+	s = (unsigned short)(x & 0xFFFF);
+}`)
+	if len(infos) != 3 {
+		t.Fatalf("found %d casts, want 3", len(infos))
+	}
+	for i, want := range []CastCategory{MaskTruncationCast, PlainCast, PlainCast} {
+		if infos[i].Category != want {
+			t.Errorf("%s reported as %s, want %s", infos[i].Expr, infos[i].Category, want)
+		}
+	}
+}
+
+func TestInductionVarCast(t *testing.T) {
+	infos := castsIn(t, `
+void f(int *a, long n, long m) {
+	long i, j;
+	for (i = 0; i < n; i++)
+		a[(int)i] = (int)m;
+	for (j = (int)n; j > 0; j -= 2) {
+		a[(int)j] = 0;
+	}
+	a[(int)i] = 0;
+}`)
+	want := []CastCategory{InductionVarCast, PlainCast, PlainCast, InductionVarCast, PlainCast}
+	if len(infos) != len(want) {
+		t.Fatalf("found %d casts, want %d", len(infos), len(want))
+	}
+	for i := range want {
+		if infos[i].Category != want[i] {
+			t.Errorf("%s (cast %d) reported as %s, want %s", infos[i].Expr, i, infos[i].Category, want[i])
+		}
+	}
+}
+
+func TestGenericPointerSuggestion(t *testing.T) {
+	infos := castsIn(t, `
+typedef struct List List;
+void *malloc(unsigned long);
+void *list_get(List *l, int i);
+struct Point { int x, y; };
+void f(List *l, int n) {
+	struct Point *p;
+	p = (struct Point*)malloc(n * sizeof(struct Point));
+	p = (struct Point*)list_get(l, 0);
+	p = (struct Point*)p;
+}`)
+	want := []struct {
+		category   CastCategory
+		suggestion string
+	}{
+		{GenericPointerCast, "use a typed allocator"},
+		{GenericPointerCast, "use a typed accessor"},
+		{PlainCast, ""},
+	}
+	if len(infos) != len(want) {
+		t.Fatalf("found %d casts, want %d", len(infos), len(want))
+	}
+	for i, w := range want {
+		if infos[i].Category != w.category || infos[i].Suggestion != w.suggestion {
+			t.Errorf("%s reported as %s with suggestion %q, want %s with %q", infos[i].Expr, infos[i].Category, infos[i].Suggestion, w.category, w.suggestion)
+		}
+	}
+}
+
+func TestSizeofOperandCast(t *testing.T) {
+	infos := castsIn(t, `
+void f(int x) {
+	unsigned long n;
+	n = sizeof((char)x);
+	n = sizeof((char)x + 1);
+	n = sizeof(char);
+	n = (unsigned long)sizeof(x);
+}`)
+	if len(infos) != 3 {
+		t.Fatalf("found %d casts, want 3", len(infos))
+	}
+	for i, want := range []CastCategory{SizeofOperandCast, PlainCast, PlainCast} {
+		if infos[i].Category != want {
+			t.Errorf("%s reported as %s, want %s", infos[i].Expr, infos[i].Category, want)
+		}
+	}
+}
+
+func TestHandleTypeConfusion(t *testing.T) {
+	prog := mustParse(t, `
+typedef void *HANDLE;
+typedef void *HWND;
+typedef HANDLE HFILE;
+void f(HANDLE h, HFILE file, void *p) {
+	HWND w;
+	w = (HWND)h;
+	w = (HWND)file;
+	w = (HWND)p;
+	h = (HANDLE)file;
+}`)
+	env := BuildEnv(prog, LP64)
+	env.Handles = map[string]bool{"HANDLE": true, "HWND": true}
+	infos := ClassifyCasts(prog, env)
+	if len(infos) != 4 {
+		t.Fatalf("found %d casts, want 4", len(infos))
+	}
+	for i, want := range []CastCategory{HandleTypeConfusion, HandleTypeConfusion, PlainCast, PlainCast} {
+		if infos[i].Category != want {
+			t.Errorf("%s reported as %s, want %s", infos[i].Expr, infos[i].Category, want)
+		}
+	}
+
+	env.Handles = nil
+	for _, info := range ClassifyCasts(prog, env) {
+		if info.Category == HandleTypeConfusion {
+			t.Errorf("%s reported as %s without configured handles", info.Expr, info.Category)
+		}
+	}
+}
+
+func TestTernaryCondCast(t *testing.T) {
+	infos := castsIn(t, `
+void f(unsigned int x, int a, int b) {
+	int r;
+	r = (unsigned char)(x & 0x100) ? a : b;
+	r = ((unsigned char)x == 0) ? a : b;
+	r = x ? (char)a : b;
+}`)
+	// The first cast narrows the mask and is reported for both.
+	if len(infos) != 4 {
+		t.Fatalf("found %d findings, want 4", len(infos))
+	}
+	for i, want := range []CastCategory{MaskTruncationCast, TernaryCondCast, TernaryCondCast, PlainCast} {
+		if infos[i].Category != want {
+			t.Errorf("%s reported as %s, want %s", infos[i].Expr, infos[i].Category, want)
+		}
+	}
+}
+
+func TestShiftSignCast(t *testing.T) {
+	infos := castsIn(t, `
+void f(int signedVal, unsigned int u) {
+	unsigned int r;
+	r = (unsigned)signedVal >> 2;
+	r = ((unsigned int)signedVal) >> 2;
+	r = (int)u >> 2;
+	r = (long)signedVal >> 2;
+	r = (unsigned)signedVal << 2;
+	r = 2 >> (unsigned)signedVal;
+}`)
+	if len(infos) != 6 {
+		t.Fatalf("found %d casts, want 6", len(infos))
+	}
+	for i, want := range []CastCategory{ShiftSignCast, ShiftSignCast, ShiftSignCast, PlainCast, PlainCast, PlainCast} {
+		if infos[i].Category != want {
+			t.Errorf("%s reported as %s, want %s", infos[i].Expr, infos[i].Category, want)
+		}
+	}
+}
+
+func TestEnumToBoolCast(t *testing.T) {
+	infos := castsIn(t, `
+#include <stdbool.h>
+enum Color { Black, White };
+typedef enum Color Color;
+void f(enum Color c, Color d, int n, unsigned int x) {
+	bool b;
+	b = (bool)c;
+	b = (_Bool)d;
+	b = (bool)n;
+	b = (bool)(x & 0x100);
+}`)
+	if len(infos) != 4 {
+		t.Fatalf("found %d casts, want 4", len(infos))
+	}
+	for i, want := range []CastCategory{EnumToBoolCast, EnumToBoolCast, PlainCast, PlainCast} {
+		if infos[i].Category != want {
+			t.Errorf("%s reported as %s, want %s", infos[i].Expr, infos[i].Category, want)
+		}
+	}
+}
+
+func TestBoolToPointerCast(t *testing.T) {
+	infos := castsIn(t, `
+#include <stdbool.h>
+void f(bool flag, _Bool b, int intValue) {
+	void *p;
+	p = (void*)flag;
+	p = (char*)b;
+	p = (void*)intValue;
+}`)
+	if len(infos) != 3 {
+		t.Fatalf("found %d casts, want 3", len(infos))
+	}
+	for i, want := range []CastCategory{BoolToPointerCast, BoolToPointerCast, PlainCast} {
+		if infos[i].Category != want {
+			t.Errorf("%s reported as %s, want %s", infos[i].Expr, infos[i].Category, want)
+		}
+	}
+}
+
+func TestUndersizedBufferCast(t *testing.T) {
+	infos := castsIn(t, `
+void *memcpy(void *dst, const void *src, unsigned long n);
+struct S { int a, b, c; };
+void f(struct S *src) {
+	char small[4];
+	char big[16];
+	struct S *s;
+	s = (struct S*)small;
+	memcpy(s, src, sizeof(struct S));
+	s = (struct S*)big;
+	memcpy(s, src, sizeof(struct S));
+	s = (struct S*)&small;
+	struct S *t = (struct S*)small;
+	memcpy(t, src, sizeof(struct S));
+	struct S *u = (struct S*)small;
+	memcpy(src, u + 1, sizeof(struct S));
+	memcpy((struct S*)small, src, sizeof(struct S));
+}`)
+	if len(infos) != 9 {
+		t.Fatalf("found %d findings, want 9", len(infos))
+	}
+	// No copy writes through the casts of &small and big, or through u.
+	for i, want := range []CastCategory{
+		ArrayDecayCast, UndersizedBufferCast, ArrayDecayCast, PlainCast,
+		ArrayDecayCast, UndersizedBufferCast, ArrayDecayCast, ArrayDecayCast, UndersizedBufferCast,
+	} {
+		if infos[i].Category != want {
+			t.Errorf("%s reported as %s, want %s", infos[i].Expr, infos[i].Category, want)
+		}
+	}
+}
+
+func TestCharIndexCast(t *testing.T) {
+	infos := castsIn(t, `
+void f(int *table, char ch, unsigned char uch, short s) {
+	int n;
+	n = table[(int)ch];
+	n = table[(int)uch];
+	n = table[(unsigned char)ch];
+	n = table[(int)s];
+	n = (int)ch;
+}`)
+	want := []struct {
+		category   CastCategory
+		suggestion string
+	}{
+		{CharIndexCast, "cast to unsigned char first"},
+		{PlainCast, ""},
+		{PlainCast, ""},
+		{PlainCast, ""},
+		{PlainCast, ""},
+	}
+	if len(infos) != len(want) {
+		t.Fatalf("found %d casts, want %d", len(infos), len(want))
+	}
+	for i, w := range want {
+		if infos[i].Category != w.category || infos[i].Suggestion != w.suggestion {
+			t.Errorf("%s reported as %s with suggestion %q, want %s with %q", infos[i].Expr, infos[i].Category, infos[i].Suggestion, w.category, w.suggestion)
+		}
+	}
+}
+
+func TestEnumUnderlyingNarrowing(t *testing.T) {
+	infos := castsIn(t, `
+typedef unsigned char uint8_t;
+enum E : uint8_t { A, B };
+enum class F : long { C };
+enum G { D };
+void f(int n, uint8_t b) {
+	enum E e;
+	e = (enum E)n;
+	e = (enum E)b;
+	e = (enum E)1;
+	e = (enum F)n;
+	e = (enum G)n;
+}`)
+	if len(infos) != 5 {
+		t.Fatalf("found %d casts, want 5", len(infos))
+	}
+	for i, want := range []CastCategory{EnumUnderlyingNarrowing, PlainCast, PlainCast, PlainCast, PlainCast} {
+		if infos[i].Category != want {
+			t.Errorf("%s reported as %s, want %s", infos[i].Expr, infos[i].Category, want)
+		}
+	}
+	if n := LP64.Sizeof(infos[0].Expr.Type); n != 1 {
+		t.Errorf("sizeof(enum E) = %d, want 1", n)
+	}
+}
+
+func TestFunctionCastCall(t *testing.T) {
+	infos := castsIn(t, `
+typedef void (*handler)(void);
+int compute(int x);
+void reset(void);
+void f(int (*fp)(int)) {
+	((void(*)(void))compute)();
+	((handler)fp)();
+	((void(*)(void))reset)();
+	((int(*)(int))compute)(1);
+	handler h = (handler)compute;
+}`)
+	if len(infos) != 5 {
+		t.Fatalf("found %d casts, want 5", len(infos))
+	}
+	for i, want := range []CastCategory{FunctionCastCall, FunctionCastCall, PlainCast, PlainCast, PlainCast} {
+		if infos[i].Category != want {
+			t.Errorf("%s reported as %s, want %s", infos[i].Expr, infos[i].Category, want)
+		}
+	}
+}
+
+func TestSmartPointerEscapeCast(t *testing.T) {
+	src := `
+struct Raw { int x; };
+class unique_ptr { struct Raw *p; };
+class Holder { struct Raw *p; };
+typedef class unique_ptr RawPtr;
+void keep(void *r);
+void f(class unique_ptr ptr, RawPtr q, class unique_ptr *pp, class Holder h) {
+	keep((struct Raw*)ptr.get());
+	keep((struct Raw*)q.release());
+	keep((struct Raw*)pp->get());
+	keep((struct Raw*)h.get());
+	keep((void*)ptr.p);
+}`
+	infos := castsIn(t, src)
+	if len(infos) != 5 {
+		t.Fatalf("found %d casts, want 5", len(infos))
+	}
+	for i, want := range []CastCategory{SmartPointerEscapeCast, SmartPointerEscapeCast, SmartPointerEscapeCast, PlainCast, PlainCast} {
+		if infos[i].Category != want {
+			t.Errorf("%s reported as %s, want %s", infos[i].Expr, infos[i].Category, want)
+		}
+	}
+
+	// The list of smart pointer types is configurable.
+	prog := mustParse(t, src)
+	env := BuildEnv(prog, LP64)
+	env.SmartPointers = map[string]bool{"Holder": true}
+	for _, info := range ClassifyCasts(prog, env) {
+		want := strings.Contains(info.Expr.String(), "h.get")
+		if got := info.Category == SmartPointerEscapeCast; got != want {
+			t.Errorf("%s reported as %s with Holder as the only smart pointer", info.Expr, info.Category)
+		}
+	}
+}
+
+func TestAllowNarrowing(t *testing.T) {
+	prog := mustParse(t, `
+#include <stdint.h>
+float to_float(double d) {
+	return (float)d;
+}
+uint8_t to_u8(uint32_t v) {
+	return (uint8_t)v;
+}
+float f(double d) {
+	return (float)d;
+}`)
+	env := BuildEnv(prog, LP64)
+	env.AllowNarrowing = map[string]bool{"to_float": true, "to_u8": true}
+	for _, info := range ClassifyCasts(prog, env) {
+		want := FloatNarrowing
+		if info.Func != "f" {
+			want = PlainCast
+		}
+		if info.Category != want {
+			t.Errorf("%s in %s reported as %s, want %s", info.Expr, info.Func, info.Category, want)
+		}
+	}
+}
+
+func TestArrayParamSizeCast(t *testing.T) {
+	infos := castsIn(t, `
+void fill(int a[static 16]);
+void fill8(int a[8]);
+void f(char *p) {
+	char small[32];
+	char big[64];
+	fill((int*)small);
+	fill((int*)big);
+	fill8((int*)small);
+	fill(*(int(*)[4])p);
+	fill(*(int(*)[16])p);
+}`)
+	var got []string
+	for _, info := range infos {
+		if info.Category == ArrayParamSizeCast {
+			got = append(got, info.Expr.String())
+		}
+	}
+	if want := "(int*)small (int (*)[4])p"; strings.Join(got, " ") != want {
+		t.Errorf("ArrayParamSizeCast findings = %q, want %q", got, want)
+	}
+}
+
+func TestLoopStrideCast(t *testing.T) {
+	infos := castsIn(t, `
+int f(int *p, int n) {
+	int i, sum = 0;
+	for (i = 0; i < n; i++) {
+		sum += ((short*)p)[i];
+		sum += *((char*)p + i);
+		sum += ((unsigned*)p)[i];
+	}
+	while (n-- > 0)
+		sum += *((long*)p + n);
+	return sum + ((short*)p)[0];
+}`)
+	var got []string
+	for _, info := range infos {
+		if info.Category == LoopStrideCast {
+			got = append(got, info.Expr.String())
+		}
+	}
+	if want := "(short*)p (char*)p (long*)p"; strings.Join(got, " ") != want {
+		t.Errorf("LoopStrideCast findings = %q, want %q", got, want)
+	}
+}
+
+func TestFloatBitPunCast(t *testing.T) {
+	infos := castsIn(t, `
+typedef unsigned int uint32_t;
+uint32_t f(float x, double *dp, uint32_t u) {
+	uint32_t bits = *(uint32_t*)&x;
+	long long wide = *(long long*)dp;
+	float back = *(float*)&u;
+	unsigned char lo = *(unsigned char*)&x;
+	uint32_t *p = (uint32_t*)&x;
+	return bits;
+}`)
+	if len(infos) != 5 {
+		t.Fatalf("found %d casts, want 5", len(infos))
+	}
+	for i, want := range []CastCategory{FloatBitPunCast, FloatBitPunCast, FloatBitPunCast, PlainCast, PlainCast} {
+		if infos[i].Category != want {
+			t.Errorf("%s reported as %s, want %s", infos[i].Expr, infos[i].Category, want)
+		}
+	}
+	if s := infos[0].Suggestion; !strings.Contains(s, "memcpy") {
+		t.Errorf("suggestion for %s = %q, want memcpy", infos[0].Expr, s)
+	}
+}
+
+func TestIndexArithCast(t *testing.T) {
+	infos := castsIn(t, `
+enum { STRIDE = 4096 };
+float f(float *a, int i, int stride, long n) {
+	float x = a[(long)(i * stride)];
+	x += a[(int)(n * STRIDE)];
+	x += a[(int)(i * stride)];
+	x += a[(long)(STRIDE * 2)];
+	x += a[(long)i];
+	return x + (long)(i * stride);
+}`)
+	if len(infos) != 6 {
+		t.Fatalf("found %d casts, want 6", len(infos))
+	}
+	for i, want := range []CastCategory{IndexArithCast, IndexArithCast, PlainCast, PlainCast, PlainCast, PlainCast} {
+		if infos[i].Category != want {
+			t.Errorf("%s reported as %s, want %s", infos[i].Expr, infos[i].Category, want)
+		}
+	}
+}
+
+func TestClassifyCastsLimited(t *testing.T) {
+	body := strings.Repeat("\tn += (int)d;\n", 200)
+	prog := mustParse(t, "int f(double d) {\n\tint n = 0;\n"+body+"\treturn n;\n}\n")
+
+	all, truncated := ClassifyCastsLimited(prog, nil, ClassifyOptions{})
+	if truncated || len(all) != 200 {
+		t.Fatalf("unlimited classification found %d casts, truncated %v; want 200, false", len(all), truncated)
+	}
+	some, truncated := ClassifyCastsLimited(prog, nil, ClassifyOptions{MaxVisits: 100})
+	if !truncated || len(some) == 0 || len(some) >= len(all) {
+		t.Fatalf("classification within 100 visits found %d casts, truncated %v; want a partial result", len(some), truncated)
+	}
+	for i := range some {
+		if some[i].Fingerprint() != all[i].Fingerprint() {
+			t.Errorf("partial finding %d = %s, want %s", i, some[i].Fingerprint(), all[i].Fingerprint())
+		}
+	}
+}
+
+func TestNestedConstCast(t *testing.T) {
+	infos := castsIn(t, `
+typedef const int cint;
+void f(const int (*rows)[10], const char **names, const int *p, cint *q, int (*m)[10]) {
+	int (*w)[10] = (int (*)[10])rows;
+	char **s = (char**)names;
+	int *a = (int*)p;
+	int *b = (int*)q;
+	const int (*r)[10] = (const int (*)[10])m;
+}`)
+	if len(infos) != 5 {
+		t.Fatalf("found %d casts, want 5", len(infos))
+	}
+	for i, want := range []CastCategory{NestedConstCast, NestedConstCast, PlainCast, PlainCast, PlainCast} {
+		if infos[i].Category != want {
+			t.Errorf("%s reported as %s, want %s", infos[i].Expr, infos[i].Category, want)
+		}
+	}
+	for i, want := range []bool{true, true, true, true, false} {
+		if got := infos[i].Expr.CastsAwayConst(); got != want {
+			t.Errorf("%s.CastsAwayConst() = %v, want %v", infos[i].Expr, got, want)
+		}
+	}
+}
+
+func TestOverflowSemanticCast(t *testing.T) {
+	infos := castsIn(t, `
+int f(int len, int extra, unsigned size) {
+	if ((unsigned)(len + extra) < size)
+		return 1;
+	if ((int)(size * 2) < len)
+		return 2;
+	if ((unsigned)len < size)
+		return 3;
+	if ((long)(len + extra) < 0)
+		return 4;
+	return (unsigned)(4 + 4);
+}`)
+	if len(infos) != 5 {
+		t.Fatalf("found %d casts, want 5", len(infos))
+	}
+	for i, want := range []CastCategory{OverflowSemanticCast, OverflowSemanticCast, PlainCast, PlainCast, PlainCast} {
+		if infos[i].Category != want {
+			t.Errorf("%s reported as %s, want %s", infos[i].Expr, infos[i].Category, want)
+		}
+	}
+}
+
+func TestVolatileRoundTripCast(t *testing.T) {
+	infos := castsIn(t, `
+int f(int x, volatile int v, int *p) {
+	int a = (int)(volatile int)x;
+	int b = (int)((volatile int)v);
+	int c = *(int*)(volatile int*)p;
+	long d = (long)(int)x;
+	return a + b + c + (int)v;
+}`)
+	var got []string
+	for _, info := range infos {
+		if info.Category == VolatileRoundTripCast {
+			got = append(got, info.Expr.String())
+		}
+	}
+	if want := "(int)(volatile int)x (int*)(volatile int*)p"; strings.Join(got, " ") != want {
+		t.Errorf("VolatileRoundTripCast findings = %q, want %q", got, want)
+	}
+	if chain := infos[0].Expr.CastChain(); len(chain) != 2 || chain[1].Type.Qual&Volatile == 0 {
+		t.Errorf("CastChain of %s = %v, want the casts to int and volatile int", infos[0].Expr, chain)
+	}
+}
+
+func TestFixedWidthNarrowing(t *testing.T) {
+	infos := castsIn(t, `
+#include <stdint.h>
+typedef int32_t s32;
+void f(int64_t a, int32_t b, s32 c, uint16_t d, int n) {
+	int32_t x = (int32_t)a;
+	int16_t y = (int16_t)b;
+	uint8_t z = (uint8_t)c;
+	int8_t w = (int8_t)d;
+	int64_t v = (int64_t)b;
+	int16_t u = (int16_t)n;
+}`)
+	if len(infos) != 6 {
+		t.Fatalf("found %d casts, want 6", len(infos))
+	}
+	for i, want := range []int{32, 16, 24, 8, 0, 0} {
+		x := infos[i].Expr
+		if got := x.FixedWidthDelta(nil); got != want {
+			t.Errorf("%s drops %d bits, want %d", x, got, want)
+		}
+		if got := infos[i].Category == FixedWidthNarrowing; got != (want > 0) {
+			t.Errorf("%s reported as %s", x, infos[i].Category)
+		}
+	}
+	if got, want := infos[1].Category.Explain(infos[1]), "narrows 32-bit int32_t to 16-bit int16_t, dropping 16 bits"; got != want {
+		t.Errorf("explanation = %q, want %q", got, want)
+	}
+}
+
+func TestTwosComplementAssumption(t *testing.T) {
+	prog := mustParse(t, `
+void f(int i, unsigned u) {
+	int a = (int)(unsigned)i;
+	long b = (long)((unsigned long)i);
+	int c = (int)(long)i;
+	int d = (int)(unsigned)u;
+}`)
+	find := func(env *Env) []string {
+		var got []string
+		for _, info := range ClassifyCasts(prog, env) {
+			if info.Category == TwosComplementAssumption {
+				got = append(got, info.Expr.String())
+			}
+		}
+		return got
+	}
+	if got := find(BuildEnv(prog, DefaultModel)); len(got) != 0 {
+		t.Errorf("reported %v by default, want nothing", got)
+	}
+	env := BuildEnv(prog, DefaultModel)
+	env.Enable = map[CastCategory]bool{TwosComplementAssumption: true}
+	if got, want := strings.Join(find(env), " "), "(int)(unsigned int)i (long)((unsigned long)i)"; got != want {
+		t.Errorf("reported %s, want %s", got, want)
+	}
+}
+
+func TestWideningIndexCast(t *testing.T) {
+	infos := castsIn(t, `
+void f(char *bytePtr, double *dp, int k) {
+	double a = ((double*)bytePtr)[k];
+	char b = ((char*)dp)[k];
+	double *c = (double*)bytePtr + k;
+	short d = ((short*)bytePtr)[k];
+}`)
+	var got []string
+	for _, info := range infos {
+		if info.Category == WideningIndexCast {
+			got = append(got, info.Expr.String())
+		}
+	}
+	if want := "(double*)bytePtr (short*)bytePtr"; strings.Join(got, " ") != want {
+		t.Fatalf("WideningIndexCast findings = %q, want %q", got, want)
+	}
+	info := infos[0]
+	if f := info.Expr.StrideFactor(nil); f != 8 {
+		t.Errorf("StrideFactor of %s = %v, want 8", info.Expr, f)
+	}
+	if got, want := WideningIndexCast.Explain(info), "indexes char* as double*, multiplying the stride of the access by 8"; got != want {
+		t.Errorf("explanation = %q, want %q", got, want)
+	}
+}
+
+func TestCommaCast(t *testing.T) {
+	infos := castsIn(t, `
+int setup(void);
+void f(long value, long other) {
+	int a = (int)(setup(), value);
+	int b = (int)(other, value);
+	int c = (int)(value, setup());
+	int d = (int)((other = 1, other), value);
+}`)
+	var got []string
+	for _, info := range infos {
+		if info.Category == CommaCast {
+			got = append(got, info.Expr.String())
+		}
+	}
+	if want := "(int)(setup(), value) (int)((other = 1, other), value)"; strings.Join(got, " ") != want {
+		t.Errorf("CommaCast findings = %q, want %q", got, want)
+	}
+}
+
+func TestLValueCast(t *testing.T) {
+	infos := castsIn(t, `
+struct S { int f; };
+void f(int val, short n, char *p, int i) {
+	*(short*)&val = n;
+	((int*)p)[i] += 1;
+	((struct S*)p)->f = val;
+	n = *(short*)&val;
+	p[(int)n] = 0;
+	val = (int)n;
+}`)
+	var got []string
+	for _, info := range infos {
+		if info.Category == LValueCast {
+			got = append(got, info.Expr.String())
+		}
+	}
+	if want := "(short*)&val (int*)p (struct S*)p"; strings.Join(got, " ") != want {
+		t.Errorf("LValueCast findings = %q, want %q", got, want)
+	}
+}
+
+func TestFormatMismatchCast(t *testing.T) {
+	infos := castsIn(t, `
+int printf(const char *fmt, ...);
+int snprintf(char *buf, unsigned long n, const char *fmt, ...);
+void f(int x, long y, double d, void *p, char *buf) {
+	printf("%d", (long)x);
+	printf("%ld", (int)y);
+	printf("%ld %s", (long)x, (char*)p);
+	printf("%5.*f|%c", (int)y, (float)d, (char)x);
+	snprintf(buf, 8, "%%d %u", (unsigned long)x);
+	printf("%f", (int)d);
+}`)
+	var got []string
+	for _, info := range infos {
+		if info.Category == FormatMismatchCast {
+			got = append(got, info.Expr.String())
+		}
+	}
+	if want := "(long)x (int)y (unsigned long)x (int)d"; strings.Join(got, " ") != want {
+		t.Errorf("FormatMismatchCast findings = %q, want %q", got, want)
+	}
+}
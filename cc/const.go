@@ -0,0 +1,175 @@
+package cc
+
+// ConstValue evaluates x as an integer constant expression, using env
+// for the sizes of types and the values of enumeration constants and
+// object-like macros. It reports false if x is not constant or uses an
+// operation it cannot evaluate, such as a division by zero.
+func (x *Expr) ConstValue(env *Env) (int64, bool) {
+	if x == nil {
+		return 0, false
+	}
+	switch x.Op {
+	case Literal:
+		switch lit := x.Text.(type) {
+		case *IntegerLiteral:
+			return int64(lit.Value), true
+		case *CharLiteral:
+			return int64(lit.Value), true
+		}
+
+	case Name:
+		if d := x.XDecl; d != nil && d.OuterType != nil && d.OuterType.Kind == Enum {
+			return enumValue(d, env)
+		}
+		if env != nil && x.XDecl == nil {
+			name := x.Text.String()
+			if v, ok := env.Enums[name]; ok {
+				return v, true
+			}
+			return env.macroValue(name)
+		}
+
+	case Paren:
+		return x.Left.ConstValue(env)
+
+	case Cast:
+		if x.Type.IsInteger() {
+			return x.Left.ConstValue(env)
+		}
+
+	case SizeofType:
+		if n := env.DataModel().Sizeof(x.Type); n > 0 {
+			return int64(n), true
+		}
+
+	case SizeofExpr:
+		if n := env.DataModel().Sizeof(x.Left.TypeOf()); n > 0 {
+			return int64(n), true
+		}
+
+	case Plus, Minus, Twid, Not:
+		v, ok := x.Left.ConstValue(env)
+		if !ok {
+			return 0, false
+		}
+		switch x.Op {
+		case Minus:
+			v = -v
+		case Twid:
+			v = ^v
+		case Not:
+			v = b2i(v == 0)
+		}
+		return v, true
+
+	case Cond:
+		c, ok := x.List[0].ConstValue(env)
+		if !ok {
+			return 0, false
+		}
+		if c != 0 {
+			return x.List[1].ConstValue(env)
+		}
+		return x.List[2].ConstValue(env)
+
+	case Add, Sub, Mul, Div, Mod, Lsh, Rsh, And, Or, Xor,
+		EqEq, NotEq, Lt, LtEq, Gt, GtEq, AndAnd, OrOr:
+		l, ok := x.Left.ConstValue(env)
+		if !ok {
+			return 0, false
+		}
+		r, ok := x.Right.ConstValue(env)
+		if !ok {
+			return 0, false
+		}
+		switch x.Op {
+		case Add:
+			return l + r, true
+		case Sub:
+			return l - r, true
+		case Mul:
+			return l * r, true
+		case Div, Mod:
+			if r == 0 {
+				return 0, false
+			}
+			if x.Op == Div {
+				return l / r, true
+			}
+			return l % r, true
+		case Lsh, Rsh:
+			if r < 0 || r >= 64 {
+				return 0, false
+			}
+			if x.Op == Lsh {
+				return l << uint(r), true
+			}
+			return l >> uint(r), true
+		case And:
+			return l & r, true
+		case Or:
+			return l | r, true
+		case Xor:
+			return l ^ r, true
+		case EqEq:
+			return b2i(l == r), true
+		case NotEq:
+			return b2i(l != r), true
+		case Lt:
+			return b2i(l < r), true
+		case LtEq:
+			return b2i(l <= r), true
+		case Gt:
+			return b2i(l > r), true
+		case GtEq:
+			return b2i(l >= r), true
+		case AndAnd:
+			return b2i(l != 0 && r != 0), true
+		case OrOr:
+			return b2i(l != 0 || r != 0), true
+		}
+	}
+	return 0, false
+}
+
+// enumValue returns the value of the enumeration constant d: its
+// initializer, or one more than the constant before it.
+func enumValue(d *Decl, env *Env) (int64, bool) {
+	var v int64
+	for _, e := range d.OuterType.Decls {
+		if e.Init != nil {
+			var ok bool
+			if v, ok = e.Init.Expr.ConstValue(env); !ok {
+				return 0, false
+			}
+		}
+		if e == d {
+			return v, true
+		}
+		v++
+	}
+	return 0, false
+}
+
+func b2i(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// macroValue evaluates the body of the object-like macro name as an
+// integer constant expression. A macro that refers back to itself, as
+// in #define A (B+1) and #define B A, is not constant.
+func (env *Env) macroValue(name string) (int64, bool) {
+	m := env.Macros[name]
+	if m == nil || m.Params != nil || env.expanding[name] {
+		return 0, false
+	}
+	inner := *env
+	inner.expanding = map[string]bool{name: true}
+	for n := range env.expanding {
+		inner.expanding[n] = true
+	}
+	return m.Expr(&Prog{}).ConstValue(&inner)
+}
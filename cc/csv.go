@@ -0,0 +1,46 @@
+package cc
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// csvHeader names the columns written by WriteCSV.
+var csvHeader = []string{"file", "line", "col", "category", "severity", "fromType", "toType", "function", "snippet", "explanation"}
+
+// WriteCSV writes infos to w as CSV with a header row, one finding per
+// row, for triage in a spreadsheet. The explanation of each finding is
+// the last column, appended after the snippet so that the columns
+// before it keep their earlier positions. Fields containing commas,
+// quotes or newlines are quoted as described in RFC 4180.
+func WriteCSV(w io.Writer, infos []CastInfo) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, info := range infos {
+		var snippet string
+		if info.Expr != nil {
+			snippet = info.Expr.String()
+		}
+		start := info.Span.Start
+		err := cw.Write([]string{
+			start.File,
+			strconv.Itoa(start.Line),
+			strconv.Itoa(start.Col),
+			string(info.Category),
+			info.Category.Severity().String(),
+			info.From,
+			info.To,
+			info.Func,
+			snippet,
+			info.Category.Explain(info),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
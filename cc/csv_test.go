@@ -0,0 +1,44 @@
+package cc
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+func TestWriteCSV(t *testing.T) {
+	prog := mustParse(t, `
+struct pair { int a, b; };
+int f(double d) {
+	return (int)d +
+	    (int)(struct pair){1, 2}.a;
+}`)
+	infos := ClassifyCasts(prog, nil)
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, infos); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV back: %v\n%s", err, buf.String())
+	}
+	if len(rows) != 3 {
+		t.Fatalf("read %d rows, want a header and 2 findings", len(rows))
+	}
+	if got := strings.Join(rows[0], ","); got != "file,line,col,category,severity,fromType,toType,function,snippet,explanation" {
+		t.Errorf("header = %q", got)
+	}
+	want := []string{"<string>", "4", "9", "PlainCast", "info", "double", "int", "f", "(int)d", "converts 64-bit double to 32-bit int"}
+	if strings.Join(rows[1], "|") != strings.Join(want, "|") {
+		t.Errorf("first row = %q, want %q", rows[1], want)
+	}
+	for i, row := range rows[1:] {
+		if len(row) != len(csvHeader) || row[len(row)-1] != infos[i].Category.Explain(infos[i]) {
+			t.Errorf("row %d = %q, want the explanation in the last of %d columns", i+1, row, len(csvHeader))
+		}
+	}
+	if snippet := rows[2][8]; snippet != infos[1].Expr.String() || !strings.Contains(snippet, ",") {
+		t.Errorf("snippet with a comma read back as %q, want %q", snippet, infos[1].Expr.String())
+	}
+}
@@ -0,0 +1,118 @@
+package cc
+
+// A DataModel gives the sizes, in bytes, of the C types whose size
+// varies between targets.
+type DataModel struct {
+	Name     string
+	Short    int
+	Int      int
+	Long     int
+	Longlong int
+	Pointer  int
+}
+
+// Common data models.
+var (
+	ILP32 = DataModel{Name: "ILP32", Short: 2, Int: 4, Long: 4, Longlong: 8, Pointer: 4}
+	LP64  = DataModel{Name: "LP64", Short: 2, Int: 4, Long: 8, Longlong: 8, Pointer: 8}
+	LLP64 = DataModel{Name: "LLP64", Short: 2, Int: 4, Long: 4, Longlong: 8, Pointer: 8}
+)
+
+// DefaultModel is the data model used when none is given.
+var DefaultModel = LP64
+
+// Sizeof returns the size of t in bytes under m,
+// or 0 if the size cannot be determined.
+func (m DataModel) Sizeof(t *Type) int {
+	size, _ := m.layout(t)
+	return size
+}
+
+// Bits returns the size of t in bits under m,
+// or 0 if the size cannot be determined.
+func (m DataModel) Bits(t *Type) int {
+	return 8 * m.Sizeof(t)
+}
+
+// SizeT returns the size of size_t in bytes under m.
+func (m DataModel) SizeT() int {
+	return m.Pointer
+}
+
+// layout returns the size and alignment of t under m.
+func (m DataModel) layout(t *Type) (size, align int) {
+	t = resolve(t)
+	if t == nil {
+		return 0, 0
+	}
+	switch t.Kind {
+	case Char, Uchar:
+		return 1, 1
+	case Short, Ushort:
+		return m.Short, m.Short
+	case Enum:
+		if t.Base != nil {
+			return m.layout(t.Base)
+		}
+		return m.Int, m.Int
+	case Int, Uint:
+		return m.Int, m.Int
+	case Long, Ulong:
+		return m.Long, m.Long
+	case Longlong, Ulonglong:
+		return m.Longlong, m.Longlong
+	case Float:
+		return 4, 4
+	case Double:
+		return 8, 8
+	case Longdouble:
+		return 16, 16
+	case Ptr:
+		return m.Pointer, m.Pointer
+	case Array:
+		n, ok := arrayLen(t)
+		size, align := m.layout(t.Base)
+		if !ok {
+			return 0, align
+		}
+		return n * size, align
+	case Struct, Union:
+		for _, d := range t.Decls {
+			fsize, falign := m.layout(d.Type)
+			if fsize == 0 {
+				return 0, 0
+			}
+			if falign > align {
+				align = falign
+			}
+			if t.Kind == Union {
+				if fsize > size {
+					size = fsize
+				}
+				continue
+			}
+			size = roundUp(size, falign) + fsize
+		}
+		return roundUp(size, align), align
+	}
+	return 0, 0
+}
+
+// arrayLen returns the number of elements of the array type t.
+func arrayLen(t *Type) (int, bool) {
+	if t.Width == nil || t.Width.Op != Literal {
+		return 0, false
+	}
+	n, ok := t.Width.Text.(*IntegerLiteral)
+	if !ok {
+		return 0, false
+	}
+	return n.Value, true
+}
+
+func roundUp(n, align int) int {
+	if align == 0 {
+		return n
+	}
+	return (n + align - 1) / align * align
+}
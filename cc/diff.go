@@ -0,0 +1,386 @@
+package cc
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// A ChangeKind says how a cast differs between two programs.
+type ChangeKind int
+
+const (
+	_        ChangeKind = iota
+	Added               // cast only in the new program
+	Removed             // cast only in the old program
+	Modified            // cast present in both programs, but different
+)
+
+var changeKindString = []string{
+	Added:    "Added",
+	Removed:  "Removed",
+	Modified: "Modified",
+}
+
+func (k ChangeKind) String() string {
+	if 0 < int(k) && int(k) < len(changeKindString) {
+		return changeKindString[k]
+	}
+	return fmt.Sprintf("ChangeKind(%d)", k)
+}
+
+// MarshalText encodes k by name, so that changes read as "Added" rather
+// than 1 in JSON output.
+func (k ChangeKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// A CastChange describes a cast that differs between two programs.
+type CastChange struct {
+	Kind ChangeKind `json:"kind"`
+	Old  *CastInfo  `json:"old,omitempty"` // cast in the old program, nil for Added
+	New  *CastInfo  `json:"new,omitempty"` // cast in the new program, nil for Removed
+
+	// Confidence reports how certain the pairing of Old and New is.
+	// It is 1 when the enclosing function and the operand match exactly
+	// and decreases towards 0 as the operands drift apart.
+	// Added and Removed changes have Confidence 1.
+	Confidence float64 `json:"confidence"`
+
+	// TypeChange is the change to the definition of the target type of
+	// New, if that changed too, in which case New has the category
+	// CastOnChangedType.
+	TypeChange *TypeChange `json:"typeChange,omitempty"`
+}
+
+// DefaultMinConfidence is the pairing threshold used when
+// DiffOptions.MinConfidence is zero.
+const DefaultMinConfidence = 0.5
+
+// DiffOptions controls how Diff pairs casts between two programs.
+type DiffOptions struct {
+	// MinConfidence is the lowest confidence at which two differing
+	// casts are paired as a modification rather than reported as a
+	// removal and an addition. Zero means DefaultMinConfidence.
+	MinConfidence float64
+
+	// PathRoot, if set, is the directory file names are made relative
+	// to before casts are matched and reported, so that a file read as
+	// src/a.c in one program and /work/src/a.c in the other is treated
+	// as the same file. File names are always cleaned.
+	PathRoot string
+
+	// Progress, if set, is called by DiffProjects each time a pair of
+	// files has been compared, with the number of pairs done so far and
+	// the total. It is called from the goroutine that called
+	// DiffProjects, never concurrently.
+	Progress func(done, total int)
+
+	// Classify, if set, makes Diff classify the casts of both programs,
+	// so that each reported cast carries its most severe category.
+	// Categories do not affect how casts pair up.
+	Classify bool
+
+	// Env, if set, builds the environment a program is classified in.
+	// The default is BuildEnv with DefaultModel.
+	Env func(p *Prog) *Env
+
+	// SingleFile, if set, makes Diff align casts by enclosing function
+	// alone, ignoring file names, as when comparing two versions of a
+	// file saved under different names.
+	SingleFile bool
+}
+
+// normPath returns the canonical spelling of file under opts.
+func (opts DiffOptions) normPath(file string) string {
+	if file == "" {
+		return ""
+	}
+	if opts.PathRoot != "" {
+		root, err1 := filepath.Abs(opts.PathRoot)
+		abs, err2 := filepath.Abs(file)
+		if err1 == nil && err2 == nil {
+			rel, err := filepath.Rel(root, abs)
+			if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return rel
+			}
+		}
+	}
+	return filepath.Clean(file)
+}
+
+// Diff compares the casts in a and b and returns the changes needed to
+// turn the casts of a into those of b. Casts are aligned per file and
+// enclosing function; casts that are identical in both programs are not
+// reported. The file names in the reported casts are normalized as
+// described by DiffOptions.PathRoot.
+func Diff(a, b *Prog, opts DiffOptions) []CastChange {
+	return opts.diffProgs(a, b, opts.normCasts, opts.normCasts)
+}
+
+// diffProgs compares a and b as Diff does, normalizing the file names of
+// their casts with normA and normB. A nil program has no casts.
+func (opts DiffOptions) diffProgs(a, b *Prog, normA, normB func([]CastInfo) []CastInfo) []CastChange {
+	min := opts.MinConfidence
+	if min == 0 {
+		min = DefaultMinConfidence
+	}
+	var old, new []CastInfo
+	if a != nil {
+		old = normA(opts.casts(a))
+	}
+	if b != nil {
+		new = normB(opts.casts(b))
+	}
+	changes := diffInfos(old, new, min, !opts.SingleFile)
+	if a != nil && b != nil {
+		opts.markChangedTypes(changes, a, b)
+	}
+	return changes
+}
+
+// markChangedTypes marks the new casts in changes whose target type was
+// redefined between a and b: both the cast and what it converts to
+// moved, so these are the riskiest changes of all. A cast keeps a
+// category more severe than CastOnChangedType, but still records the
+// TypeChange.
+func (opts DiffOptions) markChangedTypes(changes []CastChange, a, b *Prog) {
+	types := TypeChanges(a, b)
+	if len(types) == 0 {
+		return
+	}
+	changed := map[string]*TypeChange{}
+	for i := range types {
+		changed[types[i].Name] = &types[i]
+	}
+	env := opts.env(b)
+	for i := range changes {
+		c := &changes[i]
+		if c.New == nil {
+			continue
+		}
+		if tc := changedType(c.New.Expr.Type, changed, env); tc != nil {
+			if CastOnChangedType.Severity() >= c.New.Category.Severity() {
+				c.New.Category = CastOnChangedType
+			}
+			c.TypeChange = tc
+		}
+	}
+}
+
+// env returns the environment p is classified in under opts.
+func (opts DiffOptions) env(p *Prog) *Env {
+	if opts.Env != nil {
+		return opts.Env(p)
+	}
+	return BuildEnv(p, DefaultModel)
+}
+
+// casts returns the casts of p, classified if opts.Classify is set.
+func (opts DiffOptions) casts(p *Prog) []CastInfo {
+	if !opts.Classify {
+		return Casts(p)
+	}
+	var casts []CastInfo
+	for _, info := range ClassifyCasts(p, opts.env(p)) {
+		// ClassifyCasts reports the categories of a cast consecutively.
+		if n := len(casts); n > 0 && casts[n-1].Expr == info.Expr {
+			if info.Category.Severity() > casts[n-1].Category.Severity() {
+				casts[n-1] = info
+			}
+			continue
+		}
+		casts = append(casts, info)
+	}
+	return casts
+}
+
+// diffInfos compares two lists of casts with normalized file names,
+// aligning them per enclosing function and, if byFile is set, per file.
+func diffInfos(old, new []CastInfo, min float64, byFile bool) []CastChange {
+	oldFns, oldCasts := groupCasts(old, byFile)
+	newFns, newCasts := groupCasts(new, byFile)
+	fns := newFns
+	for _, fn := range oldFns {
+		if _, ok := newCasts[fn]; !ok {
+			fns = append(fns, fn)
+		}
+	}
+	var changes []CastChange
+	for _, fn := range fns {
+		changes = append(changes, diffCasts(oldCasts[fn], newCasts[fn], min)...)
+	}
+	return changes
+}
+
+// CastEquivalent reports whether a and b contain the same casts, that is,
+// whether Diff(a, b, opts) reports no changes. Formatting, comments and
+// code without casts do not affect the result.
+func CastEquivalent(a, b *Prog, opts DiffOptions) bool {
+	return len(Diff(a, b, opts)) == 0
+}
+
+// NetCastDelta returns the number of casts added by changes minus the
+// number removed. A positive result means the change set adds casts.
+func NetCastDelta(changes []CastChange) int {
+	n := 0
+	for _, c := range changes {
+		switch c.Kind {
+		case Added:
+			n++
+		case Removed:
+			n--
+		}
+	}
+	return n
+}
+
+// normCasts normalizes the file names in the spans of casts.
+func (opts DiffOptions) normCasts(casts []CastInfo) []CastInfo {
+	for i := range casts {
+		span := &casts[i].Span
+		span.Start.File = opts.normPath(span.Start.File)
+		span.End.File = opts.normPath(span.End.File)
+	}
+	return casts
+}
+
+// groupCasts groups casts by enclosing function and, if byFile is set,
+// by file, returning the group keys in order of first appearance.
+func groupCasts(casts []CastInfo, byFile bool) ([]string, map[string][]CastInfo) {
+	var fns []string
+	m := map[string][]CastInfo{}
+	for _, c := range casts {
+		key := "\x00" + c.Func
+		if byFile {
+			key = c.Span.Start.File + key
+		}
+		if _, ok := m[key]; !ok {
+			fns = append(fns, key)
+		}
+		m[key] = append(m[key], c)
+	}
+	return fns, m
+}
+
+func castKey(c CastInfo) string {
+	return c.To + "\x00" + c.Operand()
+}
+
+// diffCasts aligns the casts of a single function. Identical casts are
+// anchored using a longest common subsequence; the casts left between
+// consecutive anchors are then paired by confidence.
+func diffCasts(old, new []CastInfo, min float64) []CastChange {
+	var changes []CastChange
+	var gapOld, gapNew []CastInfo
+	flush := func() {
+		changes = append(changes, pairCasts(gapOld, gapNew, min)...)
+		gapOld, gapNew = nil, nil
+	}
+	align(len(old), len(new), func(i, j int) bool {
+		return castKey(old[i]) == castKey(new[j])
+	}, func(i, j int) {
+		switch {
+		case i < 0:
+			gapNew = append(gapNew, new[j])
+		case j < 0:
+			gapOld = append(gapOld, old[i])
+		default:
+			flush()
+		}
+	})
+	flush()
+	return changes
+}
+
+// align computes a longest common subsequence of two sequences of
+// lengths n and m under eq and calls f in order for every element:
+// f(i, j) for a matched pair, f(i, -1) for an element only in the first
+// sequence and f(-1, j) for one only in the second.
+func align(n, m int, eq func(i, j int) bool, f func(i, j int)) {
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if eq(i, j) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = imax(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case eq(i, j):
+			f(i, j)
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			f(i, -1)
+			i++
+		default:
+			f(-1, j)
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		f(i, -1)
+	}
+	for ; j < m; j++ {
+		f(-1, j)
+	}
+}
+
+// pairCasts pairs each old cast with the most similar unpaired new cast,
+// provided the pairing is at least min confident.
+func pairCasts(old, new []CastInfo, min float64) []CastChange {
+	var changes []CastChange
+	used := make([]bool, len(new))
+	for i := range old {
+		best, bestConf := -1, 0.0
+		for j := range new {
+			if used[j] {
+				continue
+			}
+			if conf := castConfidence(old[i], new[j]); conf >= min && conf > bestConf {
+				best, bestConf = j, conf
+			}
+		}
+		if best < 0 {
+			changes = append(changes, CastChange{Kind: Removed, Old: &old[i], Confidence: 1})
+			continue
+		}
+		used[best] = true
+		changes = append(changes, CastChange{Kind: Modified, Old: &old[i], New: &new[best], Confidence: bestConf})
+	}
+	for j := range new {
+		if !used[j] {
+			changes = append(changes, CastChange{Kind: Added, New: &new[j], Confidence: 1})
+		}
+	}
+	return changes
+}
+
+// castConfidence derives a pairing confidence from the edit distance
+// between the operands of two casts in the same function.
+func castConfidence(a, b CastInfo) float64 {
+	x, y := a.Operand(), b.Operand()
+	if x == y {
+		return 1
+	}
+	n := len(x)
+	if len(y) > n {
+		n = len(y)
+	}
+	return 1 - float64(StringDistance(x, y))/float64(n)
+}
+
+func imax(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,372 @@
+package cc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func mustParse(t *testing.T, src string) *Prog {
+	prog, err := ParseProg(src)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return prog
+}
+
+func TestDiffConfidence(t *testing.T) {
+	a := mustParse(t, `
+int f(double count) {
+	return (int)count;
+}
+int g(double count, double counts) {
+	return (int)count;
+}`)
+	b := mustParse(t, `
+int f(double count) {
+	return (long)count;
+}
+int g(double count, double counts) {
+	return (int)counts;
+}`)
+	changes := Diff(a, b, DiffOptions{})
+	if len(changes) != 2 {
+		t.Fatalf("Diff returned %d changes, want 2: %+v", len(changes), changes)
+	}
+	exact, fuzzy := changes[0], changes[1]
+	if exact.Kind != Modified || exact.New.Func != "f" || exact.Confidence != 1 {
+		t.Errorf("exact match = %v in %q with confidence %v, want Modified in f with confidence 1", exact.Kind, exact.New.Func, exact.Confidence)
+	}
+	if fuzzy.Kind != Modified || fuzzy.New.Func != "g" || fuzzy.Confidence >= 1 || fuzzy.Confidence < DefaultMinConfidence {
+		t.Errorf("fuzzy match = %v in %q with confidence %v, want Modified in g with confidence in [%v, 1)", fuzzy.Kind, fuzzy.New.Func, fuzzy.Confidence, DefaultMinConfidence)
+	}
+}
+
+func TestDiffUnpaired(t *testing.T) {
+	a := mustParse(t, `int f(double d, int n) { return (int)d; }`)
+	b := mustParse(t, `int f(double d, int n) { return (float)n; }`)
+	changes := Diff(a, b, DiffOptions{MinConfidence: 0.9})
+	if len(changes) != 2 || changes[0].Kind != Removed || changes[1].Kind != Added {
+		t.Fatalf("Diff = %+v, want one Removed and one Added", changes)
+	}
+	if len(Diff(a, a, DiffOptions{})) != 0 {
+		t.Errorf("Diff of a program with itself is not empty")
+	}
+}
+
+func TestDiffPathRoot(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := `int f(double d) { return (int)d; }`
+	a, err := Read("sub/../a.c", strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := Read(filepath.Join(wd, "a.c"), strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changes := Diff(a, b, DiffOptions{}); len(changes) != 2 {
+		t.Errorf("Diff without PathRoot = %+v, want the cast removed and added", changes)
+	}
+	if changes := Diff(a, b, DiffOptions{PathRoot: wd}); len(changes) != 0 {
+		t.Errorf("Diff with PathRoot = %+v, want no changes", changes)
+	}
+
+	c, err := Read("./a.c", strings.NewReader(`int f(double d) { return (long)d; }`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes := Diff(b, c, DiffOptions{PathRoot: wd})
+	if len(changes) != 1 || changes[0].Kind != Modified {
+		t.Fatalf("Diff = %+v, want one Modified", changes)
+	}
+	if file := changes[0].New.Span.Start.File; file != "a.c" {
+		t.Errorf("reported file = %q, want %q", file, "a.c")
+	}
+}
+
+func TestCastEquivalent(t *testing.T) {
+	a := mustParse(t, `
+int f(double d, int n) { return (int)d + n; }
+long g(int x) { return (long)x; }`)
+	b := mustParse(t, `
+// reformatted, with a helper added
+int
+f(double d, int n)
+{
+	int r = (int)d;
+	return r + n;
+}
+
+static int unused(int x) { return x; }
+
+long
+g(int x)
+{
+	return (long) x;
+}`)
+	if !CastEquivalent(a, b, DiffOptions{}) {
+		t.Errorf("CastEquivalent = false for a reformatted program: %+v", Diff(a, b, DiffOptions{}))
+	}
+	c := mustParse(t, `
+int f(double d, int n) { return (int)d + n; }
+long g(int x) { return (unsigned long)x; }`)
+	if CastEquivalent(a, c, DiffOptions{}) {
+		t.Errorf("CastEquivalent = true for programs with different casts")
+	}
+}
+
+func TestNetCastDelta(t *testing.T) {
+	changes := []CastChange{
+		{Kind: Added},
+		{Kind: Added},
+		{Kind: Modified},
+		{Kind: Removed},
+		{Kind: Added},
+	}
+	if n := NetCastDelta(changes); n != 2 {
+		t.Errorf("NetCastDelta = %d, want 2", n)
+	}
+	if n := NetCastDelta(changes[3:4]); n != -1 {
+		t.Errorf("NetCastDelta of one removal = %d, want -1", n)
+	}
+}
+
+func TestDiffWithHint(t *testing.T) {
+	a := mustParse(t, `
+int f(double d) { return (int)d; }
+long g(int x) { return (long)x; }`)
+	newSrc := `
+int f(double d) { return (int)d; }
+long g(int x) { return (unsigned long)x; }`
+	b := mustParse(t, newSrc)
+	start := strings.Index(newSrc, "(unsigned long)")
+	hint := []ByteRange{{File: "<string>", Start: start, End: start + len("(unsigned long)")}}
+
+	changes := DiffWithHint(a, b, hint)
+	if len(changes) != 1 || changes[0].Kind != Modified || changes[0].New.Func != "g" {
+		t.Fatalf("DiffWithHint = %+v, want one Modified in g", changes)
+	}
+
+	// Hinting only f skips the change in g.
+	f := strings.Index(newSrc, "(int)d")
+	if changes := DiffWithHint(a, b, []ByteRange{{File: "<string>", Start: f, End: f + 1}}); len(changes) != 0 {
+		t.Errorf("DiffWithHint for f = %+v, want no changes", changes)
+	}
+
+	// A range between functions cannot be localized.
+	between := strings.Index(newSrc, "\nlong")
+	if changes := DiffWithHint(a, b, []ByteRange{{File: "<string>", Start: between, End: between}}); len(changes) != 1 {
+		t.Errorf("DiffWithHint between functions = %+v, want the full diff", changes)
+	}
+}
+
+func TestDiffWithHintSpanningFunctions(t *testing.T) {
+	a := mustParse(t, `
+int f(double d) { return (int)d; }
+long g(int x) { return (long)x; }
+short h(int x) { return (short)x; }`)
+	newSrc := `
+int f(double d) { return (unsigned)d; }
+long g(int x) { return (unsigned long)x; }
+short h(int x) { return (short)x; }`
+	b := mustParse(t, newSrc)
+	start := strings.Index(newSrc, "(unsigned)")
+	end := strings.Index(newSrc, "(unsigned long)") + 1
+	changes := DiffWithHint(a, b, []ByteRange{{File: "<string>", Start: start, End: end}})
+	if len(changes) != 2 || changes[0].New.Func != "f" || changes[1].New.Func != "g" {
+		t.Errorf("DiffWithHint over f and g = %+v, want the changes in f and g", changes)
+	}
+}
+
+func TestDiffWithHintInclude(t *testing.T) {
+	// Offsets in the hint count from the start of the file, not from
+	// the start of the included header.
+	a := mustParse(t, `#include <stdint.h>
+int f(double d) { return (int)d; }
+long g(int x) { return (long)x; }
+short h(int x) { return (short)x; }`)
+	newSrc := `#include <stdint.h>
+int f(double d) { return (int)d; }
+long g(int x) { return (int64_t)x; }
+short h(int x) { return (short)x; }`
+	b := mustParse(t, newSrc)
+	start := strings.Index(newSrc, "(int64_t)")
+	hint := []ByteRange{{File: "<string>", Start: start, End: start + len("(int64_t)")}}
+	changes := DiffWithHint(a, b, hint)
+	if len(changes) != 1 || changes[0].Kind != Modified || changes[0].New.Func != "g" {
+		t.Fatalf("DiffWithHint = %+v, want one Modified in g", changes)
+	}
+	if got := changes[0].New.Span.Start.Byte; got != start {
+		t.Errorf("cast starts at byte %d, want %d", got, start)
+	}
+}
+
+func TestDiffProjectsProgress(t *testing.T) {
+	dir, err := ioutil.TempDir("", "castdiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	write := func(name, src string) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(src), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("old/a.c", "int f(double d) { return (int)d; }\n")
+	write("old/sub/b.c", "long g(int x) { return (long)x; }\n")
+	write("new/a.c", "int f(double d) { return (short)d; }\n")
+	write("new/sub/b.c", "long g(int x) { return (long)x; }\n")
+	write("new/c.cu", "float h(double d) { return (float)d; }\n")
+
+	var done []int
+	total := 0
+	opts := DiffOptions{Progress: func(n, t int) {
+		done = append(done, n)
+		total = t
+	}}
+	changes, err := DiffProjects(filepath.Join(dir, "old"), filepath.Join(dir, "new"), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 || len(done) != 3 || done[2] != 3 {
+		t.Errorf("progress reported %v of %d, want 1 to 3 of 3", done, total)
+	}
+	if len(changes) != 2 || changes[0].Kind != Modified || changes[1].Kind != Added {
+		t.Fatalf("DiffProjects = %+v, want a Modified in a.c and an Added in c.cu", changes)
+	}
+	if file := changes[1].New.Span.Start.File; file != "c.cu" {
+		t.Errorf("added cast reported in %q, want %q", file, "c.cu")
+	}
+}
+
+func TestDiffClassify(t *testing.T) {
+	a := mustParse(t, `float f(double d, int n) { return (float)n; }`)
+	b := mustParse(t, `float f(double d, int n) { return (float)d; }`)
+	changes := Diff(a, b, DiffOptions{Classify: true, MinConfidence: 0.9})
+	if len(changes) != 2 {
+		t.Fatalf("Diff = %+v, want one Removed and one Added", changes)
+	}
+	if cat := changes[0].Old.Category; cat != PlainCast {
+		t.Errorf("removed (float)n has category %q, want %s", cat, PlainCast)
+	}
+	if cat := changes[1].New.Category; cat != FloatNarrowing {
+		t.Errorf("added (float)d has category %q, want %s", cat, FloatNarrowing)
+	}
+	if cat := Diff(a, b, DiffOptions{MinConfidence: 0.9})[1].New.Category; cat != "" {
+		t.Errorf("unclassified Diff reports category %q", cat)
+	}
+}
+
+func TestDiffChangedType(t *testing.T) {
+	a := mustParse(t, `
+typedef int count_t;
+typedef int id_t;
+count_t f(int total, int totals) { return (count_t)total; }
+id_t g(int value, int values) { return (id_t)value; }`)
+	b := mustParse(t, `
+typedef long count_t;
+typedef int id_t;
+count_t f(int total, int totals) { return (count_t)totals; }
+id_t g(int value, int values) { return (id_t)values; }`)
+	changes := Diff(a, b, DiffOptions{})
+	if len(changes) != 2 {
+		t.Fatalf("Diff = %+v, want two changes", changes)
+	}
+	f, g := changes[0], changes[1]
+	if f.New.Category != CastOnChangedType || f.TypeChange == nil || f.TypeChange.Name != "count_t" {
+		t.Errorf("cast to widened count_t has category %q and type change %+v, want %s on count_t", f.New.Category, f.TypeChange, CastOnChangedType)
+	} else if f.TypeChange.Old != "typedef int count_t;" || f.TypeChange.New != "typedef long count_t;" {
+		t.Errorf("count_t changed from %q to %q", f.TypeChange.Old, f.TypeChange.New)
+	}
+	if g.New.Category != "" || g.TypeChange != nil {
+		t.Errorf("cast to unchanged id_t has category %q and type change %+v", g.New.Category, g.TypeChange)
+	}
+}
+
+func TestDiffChangedTypeEnv(t *testing.T) {
+	a := mustParse(t, `
+typedef int count_t;
+count_t f(int total, int totals) { return (count_t)total; }`)
+	b := mustParse(t, `
+typedef long count_t;
+count_t f(int total, int totals) { return (count_t)totals; }`)
+	var envs []*Prog
+	opts := DiffOptions{Env: func(p *Prog) *Env {
+		envs = append(envs, p)
+		return BuildEnv(p, DefaultModel)
+	}}
+	changes := Diff(a, b, opts)
+	if len(changes) != 1 || changes[0].New.Category != CastOnChangedType {
+		t.Fatalf("Diff = %+v, want one %s", changes, CastOnChangedType)
+	}
+	if len(envs) != 1 || envs[0] != b {
+		t.Errorf("Diff built %d environments, want one for the new program from opts.Env", len(envs))
+	}
+}
+
+func TestDiffProjectsOptions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "castdiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	oldSrc := "typedef int count_t;\nint f(double d) { return (int)d; }\ncount_t g(long n) { return (count_t)n; }\n"
+	newSrc := "typedef long count_t;\nint f(double d) { return (float)d; }\ncount_t g(long n) { return (count_t)(n + 1); }\n"
+	for _, f := range []struct{ name, src string }{{"old/a.c", oldSrc}, {"new/a.c", newSrc}} {
+		path := filepath.Join(dir, f.name)
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(f.src), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	opts := DiffOptions{Classify: true, PathRoot: dir}
+	changes, err := DiffProjects(filepath.Join(dir, "old"), filepath.Join(dir, "new"), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := Read(filepath.Join(dir, "old/a.c"), strings.NewReader(oldSrc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := Read(filepath.Join(dir, "new/a.c"), strings.NewReader(newSrc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts.SingleFile = true
+	want := Diff(a, b, opts)
+	if len(changes) != 3 || len(want) != 3 {
+		t.Fatalf("DiffProjects = %+v, Diff = %+v, want three changes each", changes, want)
+	}
+	for i, c := range changes {
+		got, w := changedInfo(c), changedInfo(want[i])
+		if c.Kind != want[i].Kind || got.Category != w.Category || c.Confidence != want[i].Confidence {
+			t.Errorf("change %d is %v %s with confidence %v, Diff says %v %s with %v", i, c.Kind, got.Category, c.Confidence, want[i].Kind, w.Category, want[i].Confidence)
+		}
+		if file := got.Span.Start.File; file != w.Span.Start.File {
+			t.Errorf("change %d reported in %q, want %q", i, file, w.Span.Start.File)
+		}
+	}
+	if c := changes[2]; c.Kind != Added || c.New.Category != CastOnChangedType {
+		t.Errorf("added cast to the redefined count_t has category %s, want %s", c.New.Category, CastOnChangedType)
+	}
+}
+
+// changedInfo returns the new cast of c, or the old one if it was removed.
+func changedInfo(c CastChange) *CastInfo {
+	if c.New != nil {
+		return c.New
+	}
+	return c.Old
+}
@@ -0,0 +1,53 @@
+package cc
+
+// HasSideEffects reports whether evaluating x may have side effects:
+// whether it contains an assignment, an increment or decrement, a call
+// or a va_arg. The answer is conservative. Every call is assumed to
+// have side effects, even of functions known to be pure, and operands
+// are searched even where C does not evaluate them, as in sizeof(x++).
+// An expression for which HasSideEffects returns false can be dropped
+// or reordered without changing the behavior of the program.
+func (x *Expr) HasSideEffects() bool {
+	if x == nil {
+		return false
+	}
+	found := false
+	Preorder(x, func(s Syntax) {
+		if y, ok := s.(*Expr); ok && !found {
+			found = y.Op.hasSideEffect()
+		}
+	})
+	return found
+}
+
+// hasSideEffect reports whether op itself, regardless of its operands,
+// may modify state.
+func (op ExprOp) hasSideEffect() bool {
+	switch op {
+	case Eq, AddEq, SubEq, MulEq, DivEq, ModEq, LshEq, RshEq, AndEq, OrEq, XorEq,
+		PreInc, PreDec, PostInc, PostDec,
+		Call, CUDACall, VaArg:
+		return true
+	}
+	return false
+}
+
+// CommaOperands returns the operands of the comma expression x in
+// evaluation order, looking through parentheses and flattening nested
+// comma expressions, so that (a, (b, c)) gives a, b and c. It returns
+// nil if x is not a comma expression.
+func (x *Expr) CommaOperands() []*Expr {
+	x = unparen(x)
+	if x == nil || x.Op != Comma {
+		return nil
+	}
+	var list []*Expr
+	for _, y := range x.List {
+		if ops := y.CommaOperands(); ops != nil {
+			list = append(list, ops...)
+		} else {
+			list = append(list, y)
+		}
+	}
+	return list
+}
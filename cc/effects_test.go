@@ -0,0 +1,55 @@
+package cc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommaOperands(t *testing.T) {
+	x, err := ParseExpr("(a, (b = 1, c))")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, y := range x.CommaOperands() {
+		got = append(got, y.String())
+	}
+	if want := "a b = 1 c"; strings.Join(got, " ") != want {
+		t.Errorf("CommaOperands = %q, want %q", got, want)
+	}
+	if x, err = ParseExpr("a + b"); err != nil {
+		t.Fatal(err)
+	}
+	if ops := x.CommaOperands(); ops != nil {
+		t.Errorf("CommaOperands of a sum = %v, want nil", ops)
+	}
+}
+
+func TestHasSideEffects(t *testing.T) {
+	prog := mustParse(t, `
+int g(int);
+void f(int a, int *p, int n) {
+	a = (int)(p[n] + a * 2) << 1;
+	a = p[n++] + 1;
+	a = (a > 0) ? g(a) : 0;
+	a = sizeof(n);
+}`)
+	var exprs []*Expr
+	Preorder(prog, func(x Syntax) {
+		if x, ok := x.(*Expr); ok && x.Op == Eq {
+			if !x.HasSideEffects() {
+				t.Errorf("HasSideEffects(%s) = false for an assignment", x)
+			}
+			exprs = append(exprs, x.Right)
+		}
+	})
+	want := []bool{false, true, true, false}
+	if len(exprs) != len(want) {
+		t.Fatalf("found %d assignments, want %d", len(exprs), len(want))
+	}
+	for i, x := range exprs {
+		if got := x.HasSideEffects(); got != want[i] {
+			t.Errorf("HasSideEffects(%s) = %v, want %v", x, got, want[i])
+		}
+	}
+}
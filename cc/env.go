@@ -0,0 +1,157 @@
+package cc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// An Env holds the program context shared by the cast analyses.
+// A nil *Env is valid and provides no context beyond the syntax itself.
+type Env struct {
+	Typedefs map[string]*Type  // typedef name to the type it names
+	Model    DataModel         // target data model; the zero value means DefaultModel
+	Symbols  map[string]*Decl  // file-scope variables and functions by name
+	Enums    map[string]int64  // enumeration constant to its value
+	Handles  map[string]bool   // typedef names of opaque handle types, set by the caller
+	Macros   map[string]*Macro // macros by name; the last definition wins
+
+	// SmartPointers names the smart pointer types, by tag or typedef
+	// name; nil means DefaultSmartPointers.
+	SmartPointers map[string]bool
+
+	// AllowNarrowing names the functions, such as conversion helpers,
+	// whose narrowing casts are sanctioned and not reported.
+	AllowNarrowing map[string]bool
+
+	// Enable turns on the opt-in categories, such as
+	// TwosComplementAssumption, which are not reported otherwise.
+	Enable map[CastCategory]bool
+
+	expanding map[string]bool // macros being evaluated by macroValue
+}
+
+// allowsNarrowing reports whether narrowing casts are allowed in fn.
+func (env *Env) allowsNarrowing(fn *Decl) bool {
+	return env != nil && fn != nil && env.AllowNarrowing[fn.Name.String()]
+}
+
+// enabled reports whether category cat is reported under env.
+func (env *Env) enabled(cat CastCategory) bool {
+	return !optInCategories[cat] || env != nil && env.Enable[cat]
+}
+
+// DefaultSmartPointers are the standard library smart pointer types.
+var DefaultSmartPointers = map[string]bool{
+	"unique_ptr": true,
+	"shared_ptr": true,
+	"auto_ptr":   true,
+}
+
+// isSmartPointer reports whether t, or a typedef name in its definition,
+// names a smart pointer type. Qualified names such as std::unique_ptr
+// match by their last component.
+func (env *Env) isSmartPointer(t *Type) bool {
+	names := DefaultSmartPointers
+	if env != nil && env.SmartPointers != nil {
+		names = env.SmartPointers
+	}
+	match := func(s fmt.Stringer) bool {
+		name := s.String()
+		if i := strings.LastIndex(name, "::"); i >= 0 {
+			name = name[i+2:]
+		}
+		return names[name]
+	}
+	for t != nil && t.Kind == TypedefType {
+		if match(t.Name) {
+			return true
+		}
+		if env != nil && env.Typedefs[t.Name.String()] != nil {
+			t = env.Typedefs[t.Name.String()]
+		} else {
+			t = t.Base
+		}
+	}
+	return t != nil && t.Tag != nil && match(t.Tag)
+}
+
+// BuildEnv collects the analysis context of p for the data model m
+// in a single pass over its declarations.
+func BuildEnv(p *Prog, m DataModel) *Env {
+	env := &Env{
+		Typedefs: map[string]*Type{},
+		Model:    m,
+		Symbols:  map[string]*Decl{},
+		Enums:    map[string]int64{},
+		Macros:   map[string]*Macro{},
+	}
+	for _, m := range p.Macros() {
+		env.Macros[m.Name] = m
+	}
+	for _, d := range p.Decls {
+		name := declName(d)
+		switch {
+		case d.Storage&Typedef != 0:
+			if name != "" {
+				env.Typedefs[name] = d.Type
+			}
+		case name != "":
+			env.Symbols[name] = d
+		}
+		env.addEnums(d.Type)
+	}
+	return env
+}
+
+// addEnums records the constants of t if it is an enumeration
+// defined in place.
+func (env *Env) addEnums(t *Type) {
+	if t == nil || t.Kind != Enum {
+		return
+	}
+	for _, e := range t.Decls {
+		if v, ok := enumValue(e, env); ok {
+			env.Enums[declName(e)] = v
+		}
+	}
+}
+
+// DataModel returns the data model of env.
+func (env *Env) DataModel() DataModel {
+	if env == nil || env.Model == (DataModel{}) {
+		return DefaultModel
+	}
+	return env.Model
+}
+
+// sizeof returns the size of t in bytes, as DataModel().Sizeof does,
+// except that the length of an array type may be any constant env can
+// evaluate, such as a macro, rather than only a literal.
+func (env *Env) sizeof(t *Type) int {
+	m := env.DataModel()
+	t = env.Resolve(t)
+	if t == nil || t.Kind != Array {
+		return m.Sizeof(t)
+	}
+	n, ok := t.Width.ConstValue(env)
+	if !ok || n < 0 {
+		return 0
+	}
+	return int(n) * env.sizeof(t.Base)
+}
+
+// Resolve returns t with any typedefs stripped, consulting env for
+// typedef names the parser could not resolve itself.
+func (env *Env) Resolve(t *Type) *Type {
+	for t != nil && t.Kind == TypedefType {
+		if t.Base != nil {
+			t = t.Base
+			continue
+		}
+		if env == nil || env.Typedefs[t.Name.String()] == nil {
+			break
+		}
+		t = env.Typedefs[t.Name.String()]
+	}
+	return t
+}
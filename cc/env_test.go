@@ -0,0 +1,27 @@
+package cc
+
+import "testing"
+
+func TestBuildEnv(t *testing.T) {
+	prog := mustParse(t, `
+typedef unsigned long size;
+enum Color { Red, Green = 5, Blue };
+size count;
+int f(void) { return Blue; }`)
+	env := BuildEnv(prog, ILP32)
+	if got := env.Resolve(env.Typedefs["size"]); got == nil || got.Kind != Ulong {
+		t.Errorf("typedef size resolves to %v, want unsigned long", got)
+	}
+	if d := env.Symbols["count"]; d == nil || d.Type.Spelling() != "size" {
+		t.Errorf("symbol count = %v, want a declaration of type size", d)
+	}
+	if env.Symbols["f"] == nil {
+		t.Errorf("function f missing from Symbols")
+	}
+	if v, ok := env.Enums["Blue"]; !ok || v != 6 {
+		t.Errorf("enum Blue = %d, %v, want 6", v, ok)
+	}
+	if env.DataModel() != ILP32 {
+		t.Errorf("DataModel() = %v, want ILP32", env.DataModel().Name)
+	}
+}
@@ -0,0 +1,91 @@
+package cc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// categoryExplanations are the rationales of the categories, in which
+// {from} and {to} stand for the operand and target types of the cast
+// and {bits} for the bits a fixed-width narrowing drops; {factor} is the
+// StrideFactor of a pointer cast.
+var categoryExplanations = map[CastCategory]string{
+	PlainCast:                "converts {from} to {to}",
+	FloatNarrowing:           "narrows {from} to {to}, losing precision",
+	SizeofTruncation:         "truncates a size of type {from} to {to}, which may not hold every object size",
+	ByteArithmeticCast:       "converts {from} to {to} for byte arithmetic, bypassing the type's stride",
+	VectorReinterpretCast:    "reinterprets vector type {from} as differently shaped {to}",
+	ImplicitDeclCast:         "casts the {from} result of an undeclared function to {to}, hiding a missing prototype",
+	UncheckedDowncast:        "downcasts {from} to {to} without checking the dynamic type",
+	ArrayDecayCast:           "reinterprets the elements of an array decayed to {from} as {to}",
+	EndianAssumptionCast:     "reads a byte of {from} through {to} at a fixed offset, which depends on byte order",
+	MaskTruncationCast:       "truncates a masked {from} to {to}, dropping bits the mask keeps",
+	InductionVarCast:         "converts the {from} loop counter to {to} on every iteration",
+	GenericPointerCast:       "converts an untyped {from} to {to} without type checking",
+	SizeofOperandCast:        "measures the size of {to} rather than of the {from} operand",
+	HandleTypeConfusion:      "converts handle type {from} to unrelated handle type {to}",
+	TernaryCondCast:          "converts the {from} condition of ?: to {to}, which may change its truth",
+	ShiftSignCast:            "changes the signedness of {from} to {to} before a right shift, changing the bits shifted in",
+	EnumToBoolCast:           "collapses enumeration {from} to {to}, merging every nonzero value",
+	UndersizedBufferCast:     "treats buffer {from} as larger {to}, which a later copy overruns",
+	CharIndexCast:            "widens plain {from} to {to} as an index, which is negative for bytes above 127 where char is signed",
+	EnumUnderlyingNarrowing:  "narrows {from} to enumeration {to}, whose underlying type may not hold the value",
+	FunctionCastCall:         "calls a function of type {from} through incompatible {to}, which is undefined",
+	StringizedCast:           "spells the cast to {to} into a macro's text instead of evaluating it",
+	SmartPointerEscapeCast:   "converts the {from} taken out of a smart pointer to {to}, escaping its ownership",
+	ArrayParamSizeCast:       "passes {from} as {to} for a parameter that requires more elements",
+	LoopStrideCast:           "indexes {from} as {to} inside a loop, changing the stride of the access",
+	FloatBitPunCast:          "reads {from} as {to}, breaking the aliasing rules",
+	IndexArithCast:           "computes an index as {from} and converts it to {to}, which may overflow or truncate",
+	NestedConstCast:          "drops a nested const from {from} in converting to {to}",
+	OverflowSemanticCast:     "converts {from} arithmetic to {to}, changing whether overflow wraps or is undefined",
+	VolatileRoundTripCast:    "converts {from} to {to}, undoing the volatile of the cast beneath it",
+	FixedWidthNarrowing:      "narrows {from} to {to}, dropping {bits} bits",
+	TwosComplementAssumption: "converts an unsigned value back to {to}, relying on two's complement wraparound to restore negative values",
+	WideningIndexCast:        "indexes {from} as {to}, multiplying the stride of the access by {factor}",
+	CommaCast:                "converts the last operand of a comma expression to {to}, after side effects that are easy to miss",
+	BoolToPointerCast:        "converts a truth value to {to}, giving a null pointer or the address 1",
+	LValueCast:               "writes through {to} to an object of another type, which may overwrite adjacent memory",
+	FormatMismatchCast:       "passes {to} to a printf conversion that expects another type",
+	CastOnChangedType:        "converts {from} to {to}, whose definition also changed",
+}
+
+// Explain returns a short rationale for the finding info of category c,
+// naming the types involved, such as "narrows 64-bit double to 32-bit
+// float, losing precision". Arithmetic types are given with their width
+// in the default data model, and fixed-width narrowings with the number
+// of bits they drop.
+func (c CastCategory) Explain(info CastInfo) string {
+	text, ok := categoryExplanations[c]
+	if !ok {
+		text = categoryExplanations[PlainCast]
+	}
+	from, to, bits, factor := info.From, info.To, "some", "some factor"
+	if x := info.Expr; x != nil {
+		from = widthOf(x.Left.TypeOf(), from)
+		to = widthOf(x.Type, to)
+		if n := x.FixedWidthDelta(nil); n > 0 {
+			bits = fmt.Sprint(n)
+		}
+		if f := x.StrideFactor(nil); f > 0 {
+			factor = fmt.Sprint(f)
+		}
+	}
+	return strings.NewReplacer("{from}", from, "{to}", to, "{bits}", bits, "{factor}", factor).Replace(text)
+}
+
+// widthOf prefixes the spelling of an arithmetic type t with its width.
+// The exact-width types of <stdint.h> have the same width in every data
+// model.
+func widthOf(t *Type, spelling string) string {
+	if !t.IsInteger() && t.FloatRank() == 0 {
+		return spelling
+	}
+	if n := fixedWidth(t, nil); n > 0 {
+		return fmt.Sprintf("%d-bit %s", n, spelling)
+	}
+	if n := DefaultModel.Bits(t); n > 0 {
+		return fmt.Sprintf("%d-bit %s", n, spelling)
+	}
+	return spelling
+}
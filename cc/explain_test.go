@@ -0,0 +1,34 @@
+package cc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	for _, c := range Categories() {
+		if categoryExplanations[c] == "" {
+			t.Errorf("category %s has no explanation", c)
+		}
+	}
+
+	infos := castsIn(t, `
+int f(double d, long n) {
+	float x = (float)d;
+	return (int)n;
+}`)
+	if len(infos) != 2 {
+		t.Fatalf("found %d casts, want 2", len(infos))
+	}
+	for i, want := range []string{"narrows 64-bit double to 32-bit float", "converts 64-bit long to 32-bit int"} {
+		if got := infos[i].Category.Explain(infos[i]); !strings.HasPrefix(got, want) {
+			t.Errorf("explanation of %s = %q, want it to start with %q", infos[i].Expr, got, want)
+		}
+	}
+
+	// Without the expression only the spellings are known.
+	info := CastInfo{From: "struct S*", To: "char*", Category: ByteArithmeticCast}
+	if got := info.Category.Explain(info); !strings.Contains(got, "struct S*") || !strings.Contains(got, "char*") {
+		t.Errorf("explanation = %q, want it to name struct S* and char*", got)
+	}
+}
@@ -20,6 +20,7 @@ type Expr struct {
 	Text         Syntax   // name or literal, for Name, Number, Goto, Arrow, Dot
 	Texts        []Syntax // list of literals, for String
 	Type         *Type    // type operand, for SizeofType, Offsetof, Cast, CastInit, VaArg
+	TypeArgs     []*Type  // type arguments, for Builtin
 	Init         *Init    // initializer, for CastInit
 	Block        []*Stmt  // for c2go
 	SourceExpr   *Expr
@@ -60,6 +61,13 @@ func (x *Expr) GetChildren() []Syntax {
 		}
 	case Arrow:
 		lst = append(lst, x.Left)
+	case Builtin:
+		for _, elem := range x.TypeArgs {
+			lst = append(lst, elem)
+		}
+		for _, elem := range x.List {
+			lst = append(lst, elem)
+		}
 	case Call:
 		lst = append(lst, x.Left)
 		if len(x.List) != 0 {
@@ -85,6 +93,8 @@ func (x *Expr) GetChildren() []Syntax {
 		lst = append(lst, x.Type)
 	case CastInit:
 		lst = append(lst, x.Type)
+	case CxxCast:
+		lst = append(lst, x.Type, x.Left)
 	case Index:
 		lst = append(lst, x.Left, x.Right)
 	case Offsetof:
@@ -119,10 +129,12 @@ const (
 	AndAnd            // Left && Right
 	AndEq             // Left &= Right
 	Arrow             // Left->Text
+	Builtin           // Text(TypeArgs, List), for __builtin_* taking type arguments
 	Call              // Left(List)
 	CUDACall          // Left(LaunchParams, List)
 	Cast              // (Type)Left
 	CastInit          // (Type){Init}
+	CxxCast           // Text<Type>(Left), for C++ static_cast and friends
 	Comma             // x, y, z; List = {x, y, z}
 	Cond              // x ? y : z; List = {x, y, z}
 	Div               // Left / Right
@@ -183,9 +195,11 @@ var exprOpString = []string{
 	AndAnd:     "AndAnd",
 	AndEq:      "AndEq",
 	Arrow:      "Arrow",
+	Builtin:    "Builtin",
 	Call:       "Call",
 	Cast:       "Cast",
 	CastInit:   "CastInit",
+	CxxCast:    "CxxCast",
 	Comma:      "Comma",
 	Cond:       "Cond",
 	Div:        "Div",
@@ -401,6 +415,9 @@ func walk(x Syntax, before, after func(Syntax), seen map[Syntax]bool) {
 			walk(y, before, after, seen)
 		}
 		walk(x.Type, before, after, seen)
+		for _, y := range x.TypeArgs {
+			walk(y, before, after, seen)
+		}
 		walk(x.Init, before, after, seen)
 		for _, y := range x.Block {
 			walk(y, before, after, seen)
@@ -0,0 +1,121 @@
+package cc
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// A FixIt is an automatic fix: the bytes of a range of a file replaced
+// by Text.
+type FixIt struct {
+	ByteRange
+	Text string
+}
+
+// FixIts returns fixes removing the redundant casts among infos, the
+// C-style casts to the type their operand is known to have exactly,
+// such as (int)n for an int n. The infos should have been found in src,
+// the text of a single file; a cast whose span in src does not spell
+// it, as for one found in an included header, is skipped. Each fix
+// deletes the parenthesized type and leaves the operand in place, so
+// that applying the fixes with ApplyFixIts gives a program that parses
+// as before without those casts. The fixes are sorted and do not
+// overlap.
+func FixIts(src []byte, infos []CastInfo) []FixIt {
+	var fixes []FixIt
+	for _, info := range infos {
+		x := info.Expr
+		if x == nil || x.Op != Cast || !exactlyTyped(x.Left) || x.Left.TypeOf().Spelling() != x.Type.Spelling() {
+			continue
+		}
+		if !spellsCast(src, x) {
+			continue
+		}
+		start, end := x.Span.Start.Byte, x.Left.Span.Start.Byte
+		fix := FixIt{ByteRange: ByteRange{File: info.Span.Start.File, Start: start, End: end}}
+		if start > 0 && fuses(src[start-1], src[end]) {
+			fix.Text = " "
+		}
+		fixes = append(fixes, fix)
+	}
+	sort.SliceStable(fixes, func(i, j int) bool { return fixes[i].Start < fixes[j].Start })
+	out := fixes[:0]
+	for _, f := range fixes {
+		if n := len(out); n > 0 && f.Start < out[n-1].End {
+			continue
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// spellsCast reports whether the span of the cast x in src holds a
+// parenthesized type followed by the text of its operand, so that the
+// offsets of x can be trusted to locate it in src.
+func spellsCast(src []byte, x *Expr) bool {
+	start, mid, end := x.Span.Start.Byte, x.Left.Span.Start.Byte, x.Left.Span.End.Byte
+	if start < 0 || start >= mid || mid >= end || end != x.Span.End.Byte || end > len(src) {
+		return false
+	}
+	typ := bytes.TrimSpace(src[start:mid])
+	if len(typ) < 2 || typ[0] != '(' || typ[len(typ)-1] != ')' {
+		return false
+	}
+	return stripSpace(string(src[mid:end])) == stripSpace(x.Left.String())
+}
+
+// stripSpace returns s without its white space.
+func stripSpace(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
+
+// exactlyTyped reports whether the type of x is known exactly: x and
+// every operand its type derives from have a type, so that no guess,
+// such as typing a call of an undeclared function, is involved.
+func exactlyTyped(x *Expr) bool {
+	if x == nil || x.TypeOf() == nil {
+		return false
+	}
+	switch x.Op {
+	case Paren, Cond, Comma, Plus, Minus, Twid, Lsh, Rsh, Add, Sub, Mul, Div, Mod, And, Or, Xor:
+		for _, y := range append([]*Expr{x.Left, x.Right}, x.List...) {
+			if y != nil && y.TypeOf() == nil {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// fuses reports whether the characters a and b would lex as part of one
+// token if nothing separated them, as the two minus signs of - (int)-n
+// do once the cast is removed.
+func fuses(a, b byte) bool {
+	const ops = "+-*/%&|^<>=!.:#"
+	word := func(c byte) bool { return isalpha(c) || isdigit(c) }
+	return word(a) && word(b) || strings.IndexByte(ops, a) >= 0 && strings.IndexByte(ops, b) >= 0
+}
+
+// ApplyFixIts returns src with fixes applied. It returns an error if
+// a fix lies outside src or two fixes overlap.
+func ApplyFixIts(src []byte, fixes []FixIt) ([]byte, error) {
+	sorted := append([]FixIt(nil), fixes...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+	var buf bytes.Buffer
+	last := 0
+	for _, f := range sorted {
+		if f.Start < 0 || f.End < f.Start || f.End > len(src) {
+			return nil, fmt.Errorf("fix-it %d-%d outside the %d bytes of the source", f.Start, f.End, len(src))
+		}
+		if f.Start < last {
+			return nil, fmt.Errorf("fix-it %d-%d overlaps the one before it", f.Start, f.End)
+		}
+		buf.Write(src[last:f.Start])
+		buf.WriteString(f.Text)
+		last = f.End
+	}
+	buf.Write(src[last:])
+	return buf.Bytes(), nil
+}
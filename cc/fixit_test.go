@@ -0,0 +1,72 @@
+package cc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFixIts(t *testing.T) {
+	src := `typedef unsigned long size_t;
+long f(int n, size_t s, double d) {
+	return (int)n + (size_t)s + (long)n + (int)d - (int)-n;
+}`
+	prog := mustParse(t, src)
+	fixes := FixIts([]byte(src), Casts(prog))
+	if len(fixes) != 3 {
+		t.Fatalf("FixIts = %+v, want fixes for (int)n, (size_t)s and (int)-n", fixes)
+	}
+	out, err := ApplyFixIts([]byte(src), fixes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "return n + s + (long)n + (int)d - -n;"; !strings.Contains(string(out), want) {
+		t.Errorf("fixed source = %q, want it to contain %q", out, want)
+	}
+	fixed := mustParse(t, string(out))
+	if n := len(Casts(fixed)); n != 2 {
+		t.Errorf("fixed source has %d casts, want 2", n)
+	}
+	if len(FixIts(out, Casts(fixed))) != 0 {
+		t.Errorf("fixed source still has fix-its")
+	}
+
+	if _, err := ApplyFixIts([]byte(src), append(fixes, fixes[0])); err == nil {
+		t.Errorf("ApplyFixIts accepted overlapping fixes")
+	}
+}
+
+func TestFixItsKeepConversions(t *testing.T) {
+	src := `long f(int b, char c, int n) {
+	return (char)(b ? c : 1000) + (int)4000000000 + (long)4000000000 + (int)(b ? n : 1) + (char)g();
+}`
+	prog := mustParse(t, src)
+	fixes := FixIts([]byte(src), Casts(prog))
+	var removed []string
+	for _, f := range fixes {
+		removed = append(removed, src[f.Start:f.End])
+	}
+	if want := "(long) (int)"; strings.Join(removed, " ") != want {
+		t.Errorf("FixIts removes %q, want %q", removed, want)
+	}
+}
+
+func TestFixItsInclude(t *testing.T) {
+	src := `#include <stdint.h>
+int32_t f(int32_t n, int m) {
+	return (int32_t)n + (int)m;
+}`
+	prog := mustParse(t, src)
+	fixes := FixIts([]byte(src), Casts(prog))
+	var removed []string
+	for _, f := range fixes {
+		removed = append(removed, src[f.Start:f.End])
+	}
+	if want := "(int32_t) (int)"; strings.Join(removed, " ") != want {
+		t.Errorf("FixIts removes %q, want %q", removed, want)
+	}
+
+	// Offsets that do not spell the casts in the text give no fixes.
+	if fixes := FixIts([]byte("\n\n"+src), Casts(prog)); len(fixes) != 0 {
+		t.Errorf("FixIts on shifted source = %+v, want none", fixes)
+	}
+}
@@ -0,0 +1,54 @@
+package cc
+
+// A CastFixture is a self-contained test case built around one finding.
+type CastFixture struct {
+	Func     string       // enclosing function, "" outside functions
+	Cast     string       // source text of the cast
+	Category CastCategory // classification of the cast
+	Source   string       // C source that reproduces the finding
+}
+
+// ExtractCasts parses src and returns a fixture for each finding in it.
+// The source of each fixture holds the declaration enclosing the cast,
+// preceded by the #include directives of src and the declarations it
+// refers to, as Minimize collects them, so that it can be parsed and
+// classified on its own.
+func ExtractCasts(src []byte) ([]CastFixture, error) {
+	prog, err := ParseProg(string(src))
+	if err != nil {
+		return nil, err
+	}
+	env := BuildEnv(prog, DefaultModel)
+
+	var fixtures []CastFixture
+	for i, d := range prog.Decls {
+		if d.Span.Start.File != "<string>" || d.Storage&Typedef != 0 || declName(d) == "" {
+			continue
+		}
+		infos := ClassifyCasts(d, env)
+		if len(infos) == 0 {
+			continue
+		}
+		source := minimalSource(prog, env, i)
+		for _, info := range infos {
+			fixtures = append(fixtures, CastFixture{
+				Func:     info.Func,
+				Cast:     info.Expr.String(),
+				Category: info.Category,
+				Source:   source,
+			})
+		}
+	}
+	return fixtures, nil
+}
+
+// fixtureDecl returns the source of the top-level declaration d.
+func fixtureDecl(d *Decl) string {
+	var p Printer
+	p.hideComments = true
+	p.Print(d)
+	if d.Body == nil {
+		p.Print(";")
+	}
+	return p.String()
+}
@@ -0,0 +1,83 @@
+package cc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractCasts(t *testing.T) {
+	src := `
+#include <wb.h>
+typedef unsigned char byte;
+int sum(int n) {
+	return n + 1;
+}
+float narrow(double d) {
+	return (float)d;
+}
+byte low(int x) {
+	return (byte)x;
+}`
+	fixtures, err := ExtractCasts([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixtures) != 2 {
+		t.Fatalf("extracted %d fixtures, want 2", len(fixtures))
+	}
+	want := []CastFixture{
+		{Func: "narrow", Cast: "(float)d", Category: FloatNarrowing},
+		{Func: "low", Cast: "(byte)x", Category: PlainCast},
+	}
+	for i, fx := range fixtures {
+		if fx.Func != want[i].Func || fx.Cast != want[i].Cast || fx.Category != want[i].Category {
+			t.Errorf("fixture %d = %s %s %s, want %s %s %s", i, fx.Func, fx.Cast, fx.Category, want[i].Func, want[i].Cast, want[i].Category)
+		}
+		if strings.Contains(fx.Source, "sum") {
+			t.Errorf("fixture %d includes unrelated function sum:\n%s", i, fx.Source)
+		}
+		again, err := ExtractCasts([]byte(fx.Source))
+		if err != nil {
+			t.Errorf("fixture %d does not parse on its own: %v\n%s", i, err, fx.Source)
+			continue
+		}
+		if len(again) != 1 || again[0].Cast != fx.Cast || again[0].Category != fx.Category {
+			t.Errorf("fixture %d reproduces %+v, want its own finding", i, again)
+		}
+	}
+}
+
+func TestExtractCastsSelfContained(t *testing.T) {
+	fixtures, err := ExtractCasts([]byte(`
+void *get(int);
+static double table[4];
+void f() {
+	(char*)get(1);
+	(float)table[0];
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixtures) != 2 || fixtures[0].Category != GenericPointerCast || fixtures[1].Category != FloatNarrowing {
+		t.Fatalf("fixtures = %+v, want a GenericPointerCast and a FloatNarrowing", fixtures)
+	}
+	for i, fx := range fixtures {
+		prog, err := ParseProg(fx.Source)
+		if err != nil {
+			t.Errorf("fixture %d does not parse: %v\n%s", i, err, fx.Source)
+			continue
+		}
+		found := false
+		for _, info := range ClassifyCasts(prog, BuildEnv(prog, DefaultModel)) {
+			if info.Expr.String() == fx.Cast {
+				found = true
+				if info.Category != fx.Category {
+					t.Errorf("fixture %d classifies %s as %s, want %s\n%s", i, fx.Cast, info.Category, fx.Category, fx.Source)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("fixture %d lost %s:\n%s", i, fx.Cast, fx.Source)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package cc
+
+import "strings"
+
+// formatArgs are the printf-style functions, mapped to the index of
+// their format argument.
+var formatArgs = map[string]int{
+	"printf":   0,
+	"fprintf":  1,
+	"sprintf":  1,
+	"snprintf": 2,
+	"dprintf":  1,
+	"asprintf": 1,
+}
+
+// A formatConv is a conversion of a printf format that consumes an
+// argument, such as %ld, or the * of a width or precision, which
+// consumes an int and is recorded with verb '*'.
+type formatConv struct {
+	verb   byte
+	length string // length modifier such as "l" or "hh"
+}
+
+// parseFormat returns the conversions of the printf format text in the
+// order they consume arguments. It reports false if the format is
+// malformed or uses a conversion it does not know, such as the
+// positional %1$d.
+func parseFormat(text string) ([]formatConv, bool) {
+	var convs []formatConv
+	for i := 0; i < len(text); i++ {
+		if text[i] != '%' {
+			continue
+		}
+		i++
+		if i < len(text) && text[i] == '%' {
+			continue
+		}
+		for i < len(text) && strings.IndexByte("-+ #0'", text[i]) >= 0 {
+			i++
+		}
+		for _, part := range []bool{false, true} { // width, then precision
+			if part {
+				if i >= len(text) || text[i] != '.' {
+					break
+				}
+				i++
+			}
+			if i < len(text) && text[i] == '*' {
+				convs = append(convs, formatConv{verb: '*'})
+				i++
+				continue
+			}
+			for i < len(text) && isdigit(text[i]) {
+				i++
+			}
+		}
+		start := i
+		for i < len(text) && strings.IndexByte("hljztLq", text[i]) >= 0 {
+			i++
+		}
+		if i >= len(text) || strings.IndexByte("diouxXcfFeEgGaAspn", text[i]) < 0 {
+			return nil, false
+		}
+		convs = append(convs, formatConv{verb: text[i], length: text[start:i]})
+	}
+	return convs, true
+}
+
+// formatText returns the text of the string literal x with the quotes
+// of its pieces removed, or false if x is not a plain string literal.
+// Escape sequences are left as written, which does not affect the %
+// conversions.
+func formatText(x *Expr) (string, bool) {
+	x = unparen(x)
+	if x == nil || x.Op != String {
+		return "", false
+	}
+	var b strings.Builder
+	for _, t := range x.Texts {
+		s := t.String()
+		if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+			return "", false
+		}
+		b.WriteString(s[1 : len(s)-1])
+	}
+	return b.String(), true
+}
+
+// mismatches reports whether a value of type t, after the default
+// argument promotions, cannot be what conversion f expects: a value of
+// another kind or, for integers and floating types, of another size
+// under env. The signedness of integers is not checked.
+func (f formatConv) mismatches(t *Type, env *Env) bool {
+	m := env.DataModel()
+	t = env.Resolve(t)
+	if t == nil {
+		return false
+	}
+	switch f.verb {
+	case '*', 'c':
+		return !t.IsInteger() || m.Sizeof(t) > m.Int
+	case 'd', 'i', 'o', 'u', 'x', 'X':
+		if !t.IsInteger() {
+			return true
+		}
+		size := m.Sizeof(t)
+		if size < m.Int {
+			size = m.Int
+		}
+		want := m.Int
+		switch f.length {
+		case "l":
+			want = m.Long
+		case "ll", "q", "j":
+			want = m.Longlong
+		case "z", "t":
+			want = m.SizeT()
+		}
+		return size != want
+	case 'f', 'F', 'e', 'E', 'g', 'G', 'a', 'A':
+		if t.FloatRank() == 0 {
+			return true
+		}
+		return (t.Kind == Longdouble) != (f.length == "L")
+	case 's', 'p', 'n':
+		return !isPointer(t)
+	}
+	return false
+}
@@ -0,0 +1,17 @@
+package cc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFormat(t *testing.T) {
+	convs, ok := parseFormat(`%-08.3lf%% %*hhd %s\\n%zu`)
+	want := []formatConv{{'f', "l"}, {'*', ""}, {'d', "hh"}, {'s', ""}, {'u', "z"}}
+	if !ok || !reflect.DeepEqual(convs, want) {
+		t.Errorf("parseFormat = %v, %v, want %v, true", convs, ok, want)
+	}
+	if _, ok := parseFormat("%1$d"); ok {
+		t.Errorf("parseFormat accepted a positional argument")
+	}
+}
@@ -0,0 +1,200 @@
+package cc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A TokenRole says what a piece of printed text is, for highlighting.
+// The low bits hold the kind of token; RoleCastType and RoleCastOperand
+// may be set in addition to mark the parts of a cast.
+type TokenRole int
+
+const (
+	RoleSpace      TokenRole = iota // white space
+	RoleKeyword                     // keyword such as return or sizeof
+	RoleOperator                    // operator or punctuation
+	RoleLiteral                     // number, character or string literal
+	RoleIdentifier                  // name of a variable, function, field or label
+	RoleType                        // word spelling a type
+	RoleComment                     // comment text
+
+	roleKinds = 0xff
+)
+
+// Flags added to the role of the tokens of a cast.
+const (
+	RoleCastType    TokenRole = 1 << (8 + iota) // token of the target type of a cast, including its parentheses
+	RoleCastOperand                             // token of the operand of a cast
+)
+
+var tokenRoleString = []string{
+	RoleSpace:      "Space",
+	RoleKeyword:    "Keyword",
+	RoleOperator:   "Operator",
+	RoleLiteral:    "Literal",
+	RoleIdentifier: "Identifier",
+	RoleType:       "Type",
+	RoleComment:    "Comment",
+}
+
+// Kind returns r without the cast flags.
+func (r TokenRole) Kind() TokenRole {
+	return r & roleKinds
+}
+
+func (r TokenRole) String() string {
+	k := r.Kind()
+	s := fmt.Sprintf("TokenRole(%d)", int(k))
+	if int(k) < len(tokenRoleString) {
+		s = tokenRoleString[k]
+	}
+	if r&RoleCastType != 0 {
+		s += "|CastType"
+	}
+	if r&RoleCastOperand != 0 {
+		s += "|CastOperand"
+	}
+	return s
+}
+
+// FormatHighlighted prints x as Printer does, calling emit with each
+// token of the output and its role instead of returning the text.
+// Concatenating the emitted text gives the printed form of x.
+// Words inside declarators, such as the parameter types of a function
+// pointer, are classified by spelling alone.
+func FormatHighlighted(x Syntax, emit func(text string, role TokenRole)) {
+	var p Printer
+	p.emit = emit
+	p.Print(x)
+	p.flushIndent()
+}
+
+// keywords are the C keywords, which are reported as RoleKeyword
+// outside of types.
+var keywords = map[string]bool{}
+
+func init() {
+	for _, kw := range []string{
+		"auto", "break", "case", "char", "const", "continue", "default", "do",
+		"double", "else", "enum", "extern", "float", "for", "goto", "if",
+		"inline", "int", "long", "register", "restrict", "return", "short",
+		"signed", "sizeof", "static", "struct", "switch", "typedef", "union",
+		"unsigned", "void", "volatile", "while", "_Atomic", "_Bool",
+		"_Thread_local", "offsetof", "va_arg", "class",
+		"static_cast", "dynamic_cast", "reinterpret_cast", "const_cast",
+	} {
+		keywords[kw] = true
+	}
+}
+
+// printToken writes text to the output. If p is highlighting, text is
+// emitted with the given role, or split into tokens classified by
+// spelling if role is negative.
+func (p *Printer) printToken(text string, role TokenRole) {
+	if p.html {
+		htmlEscaper.WriteString(&p.buf, text)
+	} else {
+		p.buf.WriteString(text)
+	}
+	if p.emit == nil || text == "" {
+		return
+	}
+	if role >= 0 {
+		p.emitToken(text, role)
+		return
+	}
+	for text != "" {
+		n, role := p.scanToken(text)
+		p.emitToken(text[:n], role)
+		text = text[n:]
+	}
+}
+
+// emitToken passes a token to the highlighting callback, adding the
+// cast flags in effect. Indentation is held back until the next token
+// so that untab can still remove it.
+func (p *Printer) emitToken(text string, role TokenRole) {
+	if role == RoleSpace && allTabs(text) {
+		p.tabs += len(text)
+		return
+	}
+	p.flushIndent()
+	p.emit(text, role|p.role&^roleKinds)
+}
+
+func (p *Printer) flushIndent() {
+	if n := p.tabs; n > 0 {
+		p.tabs = 0
+		p.emit(strings.Repeat("\t", n), RoleSpace)
+	}
+}
+
+func allTabs(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\t' {
+			return false
+		}
+	}
+	return true
+}
+
+// scanToken returns the length and role of the token at the start of s.
+func (p *Printer) scanToken(s string) (int, TokenRole) {
+	c := s[0]
+	n := 1
+	switch {
+	case isspace(c):
+		for n < len(s) && isspace(s[n]) {
+			n++
+		}
+		return n, RoleSpace
+	case isdigit(c):
+		for n < len(s) && (isalpha(s[n]) || s[n] == '.') {
+			n++
+		}
+		return n, RoleLiteral
+	case isalpha(c):
+		for n < len(s) && isalpha(s[n]) {
+			n++
+		}
+		switch {
+		case p.role.Kind() == RoleType:
+			return n, RoleType
+		case keywords[s[:n]]:
+			return n, RoleKeyword
+		}
+		return n, RoleIdentifier
+	case c == '"' || c == '\'':
+		for n < len(s) && s[n] != c {
+			if s[n] == '\\' {
+				n++
+			}
+			n++
+		}
+		if n < len(s) {
+			n++
+		}
+		return n, RoleLiteral
+	}
+	for n < len(s) && !isspace(s[n]) && !isalpha(s[n]) && s[n] != '"' && s[n] != '\'' {
+		n++
+	}
+	return n, RoleOperator
+}
+
+func isdigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// withRole prints args with role added to the roles in effect: a kind
+// replaces the current kind, flags are added to the current flags.
+func (p *Printer) withRole(role TokenRole, args ...interface{}) {
+	old := p.role
+	if role.Kind() != 0 {
+		p.role = p.role&^roleKinds | role.Kind()
+	}
+	p.role |= role &^ roleKinds
+	p.Print(args...)
+	p.role = old
+}
@@ -0,0 +1,77 @@
+package cc
+
+// A ByteRange is a span of bytes changed in a file, as taken from the
+// hunks of a unified diff. An empty range marks a deletion point.
+type ByteRange struct {
+	File       string // file name as read into the new program
+	Start, End int    // byte offsets of the range, End exclusive
+}
+
+// DiffWithHint is like Diff with default options, but compares only the
+// functions of b that overlap changedRanges, every one a range touches,
+// with the functions of the same name in a, on the assumption that
+// nothing outside the ranges has changed. Range offsets count from the
+// start of the named file, as Pos.Byte does. If a range cannot be
+// attributed to a function present in both programs, as for a change
+// between functions, to a global initializer or to a function added or
+// removed, DiffWithHint falls back to Diff.
+func DiffWithHint(a, b *Prog, changedRanges []ByteRange) []CastChange {
+	var opts DiffOptions
+	oldFns := funcsByKey(a, opts)
+	newFns := funcsByKey(b, opts)
+	changed := map[string]bool{}
+	var keys []string
+	for _, r := range changedRanges {
+		found := false
+		for _, d := range b.Decls {
+			if d.Body == nil || opts.normPath(d.Span.Start.File) != opts.normPath(r.File) || !overlaps(d.Span, r) {
+				continue
+			}
+			key := funcKey(d, opts)
+			if oldFns[key] == nil {
+				return Diff(a, b, opts)
+			}
+			found = true
+			if !changed[key] {
+				changed[key] = true
+				keys = append(keys, key)
+			}
+		}
+		if !found {
+			return Diff(a, b, opts)
+		}
+	}
+
+	var old, new []CastInfo
+	for _, k := range keys {
+		old = append(old, opts.normCasts(Casts(oldFns[k]))...)
+		new = append(new, opts.normCasts(Casts(newFns[k]))...)
+	}
+	return diffInfos(old, new, DefaultMinConfidence, true)
+}
+
+// funcsByKey returns the function definitions of p keyed as groupCasts
+// keys their casts.
+func funcsByKey(p *Prog, opts DiffOptions) map[string]*Decl {
+	m := map[string]*Decl{}
+	for _, d := range p.Decls {
+		if d.Body != nil {
+			m[funcKey(d, opts)] = d
+		}
+	}
+	return m
+}
+
+// funcKey returns the key of the function definition d.
+func funcKey(d *Decl, opts DiffOptions) string {
+	return opts.normPath(d.Span.Start.File) + "\x00" + declName(d)
+}
+
+// overlaps reports whether r touches the bytes of span. An empty range
+// touches the span it falls strictly inside of.
+func overlaps(span Span, r ByteRange) bool {
+	if r.Start == r.End {
+		return span.Start.Byte < r.Start && r.Start < span.End.Byte
+	}
+	return span.Start.Byte < r.End && r.Start < span.End.Byte
+}
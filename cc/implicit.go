@@ -0,0 +1,48 @@
+package cc
+
+import "fmt"
+
+// A CastKind names an implicit conversion C applies to the value of an
+// expression.
+type CastKind int
+
+const (
+	NoConversion           CastKind = iota
+	ArrayToPointerDecay             // array to pointer to its first element
+	FunctionToPointerDecay          // function designator to function pointer
+	IntegerPromotion                // integer narrower than int to int
+)
+
+var castKindString = []string{
+	NoConversion:           "NoConversion",
+	ArrayToPointerDecay:    "ArrayToPointerDecay",
+	FunctionToPointerDecay: "FunctionToPointerDecay",
+	IntegerPromotion:       "IntegerPromotion",
+}
+
+func (k CastKind) String() string {
+	if 0 <= int(k) && int(k) < len(castKindString) {
+		return castKindString[k]
+	}
+	return fmt.Sprintf("CastKind(%d)", k)
+}
+
+// ImplicitCast returns the implicit conversion applied to x when its
+// value is used, as it is for the operand of a cast or of arithmetic.
+// It does not account for the contexts that suppress the conversion,
+// such as the operands of sizeof and &.
+func (x *Expr) ImplicitCast() CastKind {
+	t := resolve(x.TypeOf())
+	if t == nil {
+		return NoConversion
+	}
+	switch {
+	case t.Kind == Array:
+		return ArrayToPointerDecay
+	case t.Kind == Func:
+		return FunctionToPointerDecay
+	case promote(t) != t:
+		return IntegerPromotion
+	}
+	return NoConversion
+}
@@ -14,6 +14,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // A Syntax represents any syntax element.
@@ -69,7 +70,6 @@ type Comments struct {
 type lexer struct {
 	// input
 	start int
-	byte  int
 	lexInput
 	pushed   []lexInput
 	forcePos Pos
@@ -82,12 +82,18 @@ type lexer struct {
 
 	// type checking state
 	scope       *Scope
+	outer       *Scope // enclosing scope for the parse, usually nil
 	includeSeen map[string]*Header
 
 	// output
-	errors []string
-	prog   *Prog
-	expr   *Expr
+	errors     []string
+	prog       *Prog
+	expr       *Expr
+	directives []*Directive
+
+	// parse profiling state, used only when profileParse is set
+	profile []DeclTiming
+	mark    time.Time
 }
 
 type Header struct {
@@ -102,8 +108,34 @@ func (lx *lexer) parse() {
 	if lx.wholeInput == "" {
 		lx.wholeInput = lx.input
 	}
-	lx.scope = &Scope{}
+	lx.scope = &Scope{Next: lx.outer}
+	lx.directives = nil
+	if profileParse {
+		lx.profile = nil
+		lx.mark = time.Now()
+	}
+	file := lx.file
 	yyParse(lx)
+	if lx.prog != nil {
+		lx.prog.Directives = lx.directives
+		lx.prog.files = []string{file}
+		lx.prog.profile = lx.profile
+	}
+}
+
+// timeDecls records the time spent parsing the top-level declarations
+// decls since the previous ones. Declarations sharing a declaration
+// statement, as in int a, b;, share its time equally.
+func (lx *lexer) timeDecls(decls []*Decl) {
+	if !profileParse || len(decls) == 0 {
+		return
+	}
+	now := time.Now()
+	d := now.Sub(lx.mark) / time.Duration(len(decls))
+	for _, decl := range decls {
+		lx.profile = append(lx.profile, DeclTiming{Decl: decl, Duration: d})
+	}
+	lx.mark = now
 }
 
 type lexInput struct {
@@ -113,6 +145,8 @@ type lexInput struct {
 	lastsym    string
 	file       string
 	lineno     int
+	col        int // bytes since the start of the line
+	byte       int // bytes since the start of the file
 	declSave   *Header
 }
 
@@ -189,11 +223,14 @@ func (lx *lexer) pushInclude(includeLine string) {
 }
 
 var stdMap = map[string]string{
-	"u.h":      hdr_u_h,
-	"libc.h":   hdr_libc_h,
-	"wb.h":     hdr_wb_h,
-	"stdarg.h": "",
-	"signal.h": "",
+	"u.h":            hdr_u_h,
+	"libc.h":         hdr_libc_h,
+	"wb.h":           hdr_vector_types_h + hdr_wb_h,
+	"vector_types.h": hdr_vector_types_h,
+	"stdbool.h":      hdr_stdbool_h,
+	"stdint.h":       hdr_stdint_h,
+	"stdarg.h":       "",
+	"signal.h":       "",
 }
 
 var extraMap = map[string]string{}
@@ -204,6 +241,14 @@ func AddInclude(dir string) {
 	includes = append(includes, dir)
 }
 
+var profileParse bool
+
+// ProfileParse turns recording of per-declaration parse times on or off
+// for subsequent parses. The times are reported by Prog.ParseProfile.
+func ProfileParse(on bool) {
+	profileParse = on
+}
+
 func (lx *lexer) findInclude(name string, std bool) (string, []byte, error) {
 	if std {
 		if redir, ok := stdMap[name]; ok {
@@ -247,7 +292,7 @@ func (lx *lexer) pos() Pos {
 	if lx.forcePos.Line != 0 {
 		return lx.forcePos
 	}
-	return Pos{lx.file, lx.lineno, lx.byte}
+	return Pos{lx.file, lx.lineno, lx.byte, lx.col + 1}
 }
 func (lx *lexer) span() Span {
 	p := lx.pos()
@@ -270,6 +315,11 @@ func span(l1, l2 Span) Span {
 
 func (lx *lexer) skip(i int) {
 	lx.lineno += strings.Count(lx.input[:i], "\n")
+	if nl := strings.LastIndex(lx.input[:i], "\n"); nl >= 0 {
+		lx.col = i - nl - 1
+	} else {
+		lx.col += i
+	}
 	lx.input = lx.input[i:]
 	lx.byte += i
 }
@@ -309,6 +359,17 @@ func (lx *lexer) comment(i int) {
 	lx.comments = append(lx.comments, c)
 }
 
+// parseInt returns the value of the C integer constant s,
+// which may be hexadecimal or octal and carry a u or l suffix.
+func parseInt(s string) int {
+	s = strings.TrimRight(s, "uUlL")
+	if v, err := strconv.ParseInt(s, 0, 64); err == nil {
+		return int(v)
+	}
+	v, _ := strconv.ParseUint(s, 0, 64)
+	return int(v)
+}
+
 func isalpha(c byte) bool {
 	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || c == '_' || c >= 0x80 || '0' <= c && c <= '9'
 }
@@ -348,6 +409,12 @@ Restart:
 		lx.skip(i)
 		goto Restart
 	}
+	if c == '\\' && len(in) > 1 && in[1] == '\n' {
+		// A line continuation between tokens, as between the
+		// pieces of a long string literal.
+		lx.skip(2)
+		goto Restart
+	}
 
 	i := 0
 	switch c {
@@ -360,7 +427,11 @@ Restart:
 			i++
 		}
 		str := in[:i]
+		start := lx.pos()
 		lx.skip(i)
+		if len(lx.pushed) == 0 {
+			lx.directives = append(lx.directives, &Directive{Span: Span{start, lx.pos()}, Text: str})
+		}
 		if strings.HasPrefix(str, "#include") {
 			lx.pushInclude(str)
 		}
@@ -390,7 +461,9 @@ Restart:
 		}
 		i++ // for the quote
 		lx.sym(i)
-		yy.str = lx.tok
+		// Join continued lines; the span still covers the
+		// physical lines of the literal.
+		yy.str = strings.Replace(lx.tok, "\\\n", "", -1)
 		if q == '"' {
 			return tokString
 		} else {
@@ -417,9 +490,11 @@ Restart:
 			i++
 		}
 		lx.sym(i)
+		if resTok == tokInteger && !strings.HasPrefix(lx.tok, "0x") && !strings.HasPrefix(lx.tok, "0X") && strings.ContainsAny(lx.tok, "eE") {
+			resTok = tokReal
+		}
 		if resTok == tokInteger {
-			ival, _ := strconv.Atoi(lx.tok)
-			yy.intlit = &IntegerLiteral{Value: ival}
+			yy.intlit = &IntegerLiteral{Value: parseInt(lx.tok), Text: lx.tok}
 		} else {
 			fval, _ := strconv.ParseFloat(lx.tok, 64)
 			yy.reallit = &RealLiteral{Value: fval}
@@ -504,7 +579,7 @@ Restart:
 			lx.tok = "struct"
 		}
 		yy.str = lx.tok
-		if t := tokId[lx.tok]; t != 0 {
+		if t := tokId[lx.tok]; t != 0 && (!cxxKeywords[lx.tok] || lx.cxxKeyword()) {
 			return int(t)
 		}
 		yy.decl = lx.lookupDecl(&SymbolLiteral{Value: lx.tok})
@@ -526,6 +601,51 @@ Restart:
 	return tokError
 }
 
+// cxxKeywords are the C++ keywords of tokId, which are ordinary
+// identifiers in C, as in struct S { int class; }.
+var cxxKeywords = map[string]bool{
+	"class":            true,
+	"const_cast":       true,
+	"dynamic_cast":     true,
+	"reinterpret_cast": true,
+	"static_cast":      true,
+}
+
+// cxxKeyword reports whether the word of cxxKeywords just read is used
+// as a C++ keyword: only in a C++ translation unit, and there only
+// where C++ needs it, with class followed by a name, as in class Base
+// or enum class E, and a named cast followed by its <. The language is
+// that of the file being compiled, not of the header being read, so a
+// .h file is C++ only when a C++ file includes it.
+func (lx *lexer) cxxKeyword() bool {
+	unit := lx.file
+	if len(lx.pushed) > 0 {
+		unit = lx.pushed[0].file
+	}
+	if !isCxxFile(unit) {
+		return false
+	}
+	next := strings.TrimLeft(lx.input, " \t\r\n")
+	if next == "" {
+		return false
+	}
+	if lx.tok == "class" {
+		return isalpha(next[0]) && !isdigit(next[0])
+	}
+	return next[0] == '<'
+}
+
+// isCxxFile reports whether the file name is that of C++ or CUDA
+// source. A program parsed from a string, named <string>, may be
+// either, so it is read as C++.
+func isCxxFile(name string) bool {
+	switch filepath.Ext(name) {
+	case ".cc", ".cpp", ".cxx", ".hpp", ".cu":
+		return true
+	}
+	return name == "<string>"
+}
+
 func (lx *lexer) Error(s string) {
 	lx.Errorf("%s near %s", s, lx.lastsym)
 }
@@ -535,14 +655,15 @@ func (lx *lexer) Errorf(format string, args ...interface{}) {
 }
 
 type Pos struct {
-	File string
-	Line int
-	Byte int
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Byte int    `json:"byte"`
+	Col  int    `json:"col"` // 1-based byte offset within the line
 }
 
 type Span struct {
-	Start Pos
-	End   Pos
+	Start Pos `json:"start"`
+	End   Pos `json:"end"`
 }
 
 func (l Span) String() string {
@@ -637,6 +758,18 @@ var tokId = map[string]int32{
 	"__shared__": tokShared,
 	"restrict":   tokRestrict,
 
+	"_Atomic":       tokAtomic,
+	"_Thread_local": tokThreadLocal,
+
+	"__builtin_choose_expr":        tokBuiltin,
+	"__builtin_offsetof":           tokBuiltin,
+	"__builtin_types_compatible_p": tokBuiltin,
+	"class":                        tokClass,
+	"const_cast":                   tokCxxCast,
+	"dynamic_cast":                 tokCxxCast,
+	"reinterpret_cast":             tokCxxCast,
+	"static_cast":                  tokCxxCast,
+
 	"ARGBEGIN": tokARGBEGIN,
 	"ARGEND":   tokARGEND,
 	"AUTOLIB":  tokAUTOLIB,
@@ -772,10 +905,10 @@ func (x byStart) Less(i, j int) bool {
 	pj := x[j].GetSpan()
 	// Order by start byte, leftmost first,
 	// and break ties by choosing outer before inner.
-	if pi.Start.Byte != pj.Start.Byte {
-		return pi.Start.Byte < pj.Start.Byte
+	if c := comparePos(pi.Start, pj.Start); c != 0 {
+		return c < 0
 	}
-	return pi.End.Byte > pj.End.Byte
+	return comparePos(pi.End, pj.End) > 0
 }
 
 type byEnd []Syntax
@@ -787,10 +920,28 @@ func (x byEnd) Less(i, j int) bool {
 	pj := x[j].GetSpan()
 	// Order by end byte, leftmost first,
 	// and break ties by choosing inner before outer.
-	if pi.End.Byte != pj.End.Byte {
-		return pi.End.Byte < pj.End.Byte
+	if c := comparePos(pi.End, pj.End); c != 0 {
+		return c < 0
+	}
+	return comparePos(pi.Start, pj.Start) > 0
+}
+
+// comparePos orders positions by file and then by byte offset.
+// Byte offsets count from the start of each file, so positions
+// in different files (an included header, or another file read
+// by ReadMany) are only comparable by file name.
+func comparePos(p, q Pos) int {
+	switch {
+	case p.File < q.File:
+		return -1
+	case p.File > q.File:
+		return +1
+	case p.Byte < q.Byte:
+		return -1
+	case p.Byte > q.Byte:
+		return +1
 	}
-	return pi.Start.Byte > pj.Start.Byte
+	return 0
 }
 
 // assignComments attaches comments to nearby syntax.
@@ -807,12 +958,14 @@ func (lx *lexer) assignComments() {
 			line = append(line, com)
 		}
 	}
+	sort.SliceStable(line, func(i, j int) bool { return comparePos(line[i].Start, line[j].Start) < 0 })
+	sort.SliceStable(suffix, func(i, j int) bool { return comparePos(suffix[i].Start, suffix[j].Start) < 0 })
 
 	// Assign line comments to syntax immediately following.
 	for _, x := range lx.pre {
 		start := x.GetSpan().Start
 		xcom := x.GetComments()
-		for len(line) > 0 && start.Byte >= line[0].Start.Byte {
+		for len(line) > 0 && comparePos(start, line[0].Start) >= 0 {
 			xcom.Before = append(xcom.Before, line[0])
 			line = line[1:]
 		}
@@ -847,7 +1000,7 @@ func (lx *lexer) assignComments() {
 			continue
 		}
 		xcom := x.GetComments()
-		for len(suffix) > 0 && end.Byte <= suffix[len(suffix)-1].Start.Byte {
+		for len(suffix) > 0 && comparePos(end, suffix[len(suffix)-1].Start) <= 0 {
 			xcom.Suffix = append(xcom.Suffix, suffix[len(suffix)-1])
 			suffix = suffix[:len(suffix)-1]
 		}
@@ -0,0 +1,72 @@
+package cc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestContinuedString(t *testing.T) {
+	prog := mustParse(t, `int printf(const char *fmt, ...);
+void f(double d) {
+	printf("value: \
+%d" \
+	" units\n", (int)d);
+}
+`)
+	casts := Casts(prog)
+	if len(casts) != 1 {
+		t.Fatalf("found %d casts, want 1", len(casts))
+	}
+	if pos := casts[0].Span.Start; pos.Line != 5 || pos.Col != 14 {
+		t.Errorf("(int)d reported at line %d, column %d, want line 5, column 14", pos.Line, pos.Col)
+	}
+	var str *Expr
+	Walk(prog, func(x Syntax) {
+		if x, ok := x.(*Expr); ok && x.Op == String {
+			str = x
+		}
+	}, func(Syntax) {})
+	if str == nil || len(str.Texts) != 2 {
+		t.Fatalf("format string not parsed as two literals")
+	}
+	if s := str.Texts[0].String(); s != `"value: %d"` {
+		t.Errorf("continued literal = %#q, want %#q", s, `"value: %d"`)
+	}
+	if span := str.Texts[0].GetSpan(); span.Start.Line != 3 || span.End.Line != 4 {
+		t.Errorf("continued literal spans lines %d to %d, want 3 to 4", span.Start.Line, span.End.Line)
+	}
+}
+
+func TestCxxKeywordsInC(t *testing.T) {
+	src := `
+struct S { int class; };
+int static_cast(int);
+int f(struct S *s) {
+	int class = 1;
+	return static_cast(class + s->class) < class;
+}`
+	mustParse(t, src)
+	if _, err := Read("a.c", strings.NewReader(src+"int g(int class) { return (long)class > class; }\n")); err != nil {
+		t.Errorf("C file using C++ keywords as names: %v", err)
+	}
+
+	// A header is C unless a C++ file includes it.
+	hdr := "int g(int class) { return (long)class > class; }\n"
+	if _, err := Read("a.h", strings.NewReader(hdr)); err != nil {
+		t.Errorf("C header using C++ keywords as names: %v", err)
+	}
+	dir, err := ioutil.TempDir("", "castdiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "g.h"), []byte(hdr), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Read(filepath.Join(dir, "a.c"), strings.NewReader("#include \"g.h\"\n")); err != nil {
+		t.Errorf("C file including a header using C++ keywords as names: %v", err)
+	}
+}
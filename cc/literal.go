@@ -45,6 +45,7 @@ type IntegerLiteral struct {
 	SyntaxInfo
 	Id    int
 	Value int
+	Text  string // spelling in the source, with any suffix; "" if synthesized
 }
 
 func (x *IntegerLiteral) GetId() int {
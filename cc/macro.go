@@ -0,0 +1,169 @@
+package cc
+
+import "strings"
+
+// A Macro is a #define directive of a program.
+type Macro struct {
+	Span   Span
+	Name   string
+	Params []string // parameter names; nil for an object-like macro
+	Body   string   // replacement text, with line continuations removed
+}
+
+// Macros returns the macros defined by the #define directives of x,
+// in source order.
+func (x *Prog) Macros() []*Macro {
+	var macros []*Macro
+	for _, d := range x.Directives {
+		if m := parseDefine(d); m != nil {
+			macros = append(macros, m)
+		}
+	}
+	return macros
+}
+
+// parseDefine returns the macro defined by d, or nil if d is not a
+// well-formed #define.
+func parseDefine(d *Directive) *Macro {
+	text := strings.Replace(d.Text, "\\\n", " ", -1)
+	text = strings.TrimSpace(strings.TrimPrefix(text, "#"))
+	if !strings.HasPrefix(text, "define") {
+		return nil
+	}
+	text = strings.TrimLeft(text[len("define"):], " \t")
+	i := 0
+	for i < len(text) && isalpha(text[i]) {
+		i++
+	}
+	if i == 0 {
+		return nil
+	}
+	m := &Macro{Span: d.Span, Name: text[:i]}
+	text = text[i:]
+	if strings.HasPrefix(text, "(") {
+		end := strings.Index(text, ")")
+		if end < 0 {
+			return nil
+		}
+		m.Params = []string{}
+		for _, p := range strings.Split(text[1:end], ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				m.Params = append(m.Params, p)
+			}
+		}
+		text = text[end+1:]
+	}
+	m.Body = strings.TrimSpace(text)
+	return m
+}
+
+// Expr parses the body of m as an expression in the context of the
+// typedefs declared in p, so that casts to typedef names are understood.
+// It returns nil if the body is not an expression.
+func (m *Macro) Expr(p *Prog) *Expr {
+	if m.Body == "" {
+		return nil
+	}
+	sc := &Scope{Decl: map[string]*Decl{}}
+	for _, d := range p.Decls {
+		if d.Storage&Typedef != 0 && d.Name != nil {
+			sc.Decl[d.Name.String()] = d
+		}
+	}
+	lx := &lexer{
+		start: startExpr,
+		outer: sc,
+		lexInput: lexInput{
+			input:  m.Body + "\n",
+			file:   m.Span.Start.File,
+			lineno: m.Span.Start.Line,
+		},
+	}
+	lx.parse()
+	if lx.errors != nil {
+		return nil
+	}
+	return lx.expr
+}
+
+// Stringizes reports whether the i'th parameter of m is an operand of
+// the # or ## operator in its body, so that the argument is used as
+// text rather than as an expression.
+func (m *Macro) Stringizes(i int) bool {
+	if i < 0 || i >= len(m.Params) {
+		return false
+	}
+	toks := macroTokens(m.Body)
+	for j, tok := range toks {
+		if tok != m.Params[i] {
+			continue
+		}
+		if j > 0 && (toks[j-1] == "#" || toks[j-1] == "##") || j+1 < len(toks) && toks[j+1] == "##" {
+			return true
+		}
+	}
+	return false
+}
+
+// macroTokens splits a macro body into identifiers, # and ## operators
+// and single characters, dropping white space.
+func macroTokens(body string) []string {
+	var toks []string
+	for i := 0; i < len(body); {
+		n := 1
+		switch c := body[i]; {
+		case isspace(c):
+			i++
+			continue
+		case isalpha(c):
+			for i+n < len(body) && isalpha(body[i+n]) {
+				n++
+			}
+		case c == '#' && strings.HasPrefix(body[i:], "##"):
+			n = 2
+		}
+		toks = append(toks, body[i:i+n])
+		i += n
+	}
+	return toks
+}
+
+// CastProducingMacros returns the macros of root whose expansion
+// introduces a cast, mapped to the number of times each is used.
+// Macros that are defined but never used are left out.
+func CastProducingMacros(root *Prog) map[string]int {
+	producers := map[string]*Macro{}
+	for _, m := range root.Macros() {
+		if len(Casts(m.Expr(root))) > 0 {
+			producers[m.Name] = m
+		} else {
+			delete(producers, m.Name)
+		}
+	}
+	counts := map[string]int{}
+	Walk(root, func(x Syntax) {
+		if m, ok := macroUse(x, producers); ok {
+			counts[m.Name]++
+		}
+	}, func(Syntax) {})
+	return counts
+}
+
+// macroUse reports the macro of macros expanded by x: a call of a
+// function-like macro or a mention of an object-like one. Macro names
+// are unknown to the parser, so they appear as undeclared names.
+func macroUse(x Syntax, macros map[string]*Macro) (*Macro, bool) {
+	e, ok := x.(*Expr)
+	if !ok {
+		return nil, false
+	}
+	switch {
+	case e.Op == Call && e.Left.Op == Name && e.Left.XDecl == nil:
+		m := macros[e.Left.Text.String()]
+		return m, m != nil && m.Params != nil
+	case e.Op == Name && e.XDecl == nil:
+		m := macros[e.Text.String()]
+		return m, m != nil && m.Params == nil
+	}
+	return nil, false
+}
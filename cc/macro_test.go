@@ -0,0 +1,98 @@
+package cc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCastProducingMacros(t *testing.T) {
+	prog := mustParse(t, `
+typedef unsigned char u8;
+#define AS_INT(x) ((int)(x))
+#define LOW(x) \
+	((u8)(x))
+#define NOTHING ((char*)0)
+#define N 4
+int f(double d, long v) {
+	return AS_INT(d) + AS_INT(d * 2) + LOW(v) + N;
+}`)
+	want := map[string]int{"AS_INT": 2, "LOW": 1}
+	if got := CastProducingMacros(prog); !reflect.DeepEqual(got, want) {
+		t.Errorf("CastProducingMacros() = %v, want %v", got, want)
+	}
+}
+
+func TestStringizedCast(t *testing.T) {
+	prog := mustParse(t, `
+#define STR(x) #x
+#define CAT(a, b) a ## b
+#define TWICE(x) ((x) + (x))
+const char *f(double d, int n) {
+	TWICE((int)d);
+	CAT(n, (long)d);
+	return STR((int)d + 1);
+}`)
+	var got []string
+	for _, info := range ClassifyCasts(prog, BuildEnv(prog, DefaultModel)) {
+		if info.Category == StringizedCast {
+			got = append(got, info.Expr.String())
+		}
+	}
+	want := []string{"(long)d", "(int)d"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("StringizedCast findings = %q, want %q", got, want)
+	}
+
+	m := prog.Macros()[1]
+	if !m.Stringizes(0) || !m.Stringizes(1) {
+		t.Errorf("%s does not report both parameters as pasted", m.Name)
+	}
+	if prog.Macros()[2].Stringizes(0) {
+		t.Errorf("TWICE reported as stringizing its argument")
+	}
+}
+
+func TestMacroConstValue(t *testing.T) {
+	prog := mustParse(t, `
+#define WORDS 4
+#define BUFSZ (WORDS * 8)
+#define LOOP (LOOP + 1)
+void fill(int a[static 16]);
+void f(char *p) {
+	fill(*(int(*)[WORDS])p);
+	char (*b)[BUFSZ] = (char(*)[BUFSZ])p;
+	char (*l)[LOOP] = (char(*)[LOOP])p;
+}`)
+	env := BuildEnv(prog, DefaultModel)
+	infos := ClassifyCasts(prog, env)
+	var sizes []string
+	casts := map[string]*Expr{}
+	for _, info := range infos {
+		if info.Category == ArrayParamSizeCast {
+			sizes = append(sizes, info.Expr.String())
+		}
+		casts[info.Expr.String()] = info.Expr
+	}
+	if want := "(int (*)[WORDS])p"; strings.Join(sizes, " ") != want {
+		t.Errorf("ArrayParamSizeCast findings = %q, want %q", sizes, want)
+	}
+	for _, tt := range []struct {
+		cast string
+		n    int64
+		ok   bool
+	}{
+		{"(char (*)[BUFSZ])p", 32, true},
+		{"(char (*)[LOOP])p", 0, false},
+	} {
+		x := casts[tt.cast]
+		if x == nil {
+			t.Errorf("cast %s not found", tt.cast)
+			continue
+		}
+		n, ok := elemType(x.Type).Width.ConstValue(env)
+		if n != tt.n || ok != tt.ok {
+			t.Errorf("length of %s = %d, %v, want %d, %v", tt.cast, n, ok, tt.n, tt.ok)
+		}
+	}
+}
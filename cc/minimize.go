@@ -0,0 +1,155 @@
+package cc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Minimize reduces src to a snippet that still reproduces finding: the
+// #include directives of src, the declaration enclosing the cast and the
+// file-scope declarations it refers to, directly or through other such
+// declarations. Functions referred to are kept as prototypes only.
+// The snippet is parsed and classified again before it is returned,
+// and Minimize fails if the finding no longer appears in it.
+func Minimize(src []byte, finding CastInfo) ([]byte, error) {
+	prog, err := ParseProg(string(src))
+	if err != nil {
+		return nil, err
+	}
+	env := BuildEnv(prog, DefaultModel)
+	target := -1
+	for i, d := range prog.Decls {
+		if d.Span.Start.File == "<string>" && reproduces(ClassifyCasts(d, env), finding) {
+			target = i
+			break
+		}
+	}
+	if target < 0 {
+		return nil, fmt.Errorf("finding %s not found", finding.Fingerprint())
+	}
+	out := minimalSource(prog, env, target)
+
+	min, err := ParseProg(out)
+	if err != nil {
+		return nil, fmt.Errorf("minimized source does not parse: %v", err)
+	}
+	if !reproduces(ClassifyCasts(min, BuildEnv(min, DefaultModel)), finding) {
+		return nil, fmt.Errorf("minimized source does not reproduce %s", finding.Fingerprint())
+	}
+	return []byte(out), nil
+}
+
+// minimalSource returns the source of the declaration prog.Decls[target]
+// preceded by the #include directives of prog and the file-scope
+// declarations the target refers to, directly or through other such
+// declarations. Functions referred to are kept as prototypes only.
+func minimalSource(prog *Prog, env *Env, target int) string {
+	var prelude []string
+	for _, d := range prog.Directives {
+		if strings.HasPrefix(strings.TrimSpace(strings.TrimPrefix(d.Text, "#")), "include") {
+			prelude = append(prelude, d.Text)
+		}
+	}
+
+	// Index the declarations of prog by what they define.
+	defs := map[string]int{}
+	define := func(key string, i int) {
+		if _, ok := defs[key]; !ok {
+			defs[key] = i
+		}
+	}
+	for i, d := range prog.Decls {
+		if d.Span.Start.File != "<string>" {
+			continue // from an included file
+		}
+		name := declName(d)
+		switch {
+		case d.Storage&Typedef != 0:
+			if env.Typedefs[name] != nil {
+				define("typedef "+name, i)
+			}
+		case name != "" && env.Symbols[name] != nil:
+			define(name, i)
+		}
+		if t := d.Type; t != nil && t.Tag != nil && t.Decls != nil {
+			define("tag "+t.Tag.String(), i)
+			for _, e := range t.Decls {
+				if t.Kind == Enum {
+					define(declName(e), i)
+				}
+			}
+		}
+	}
+
+	// Collect the declarations the target depends on.
+	need := map[int]bool{target: true}
+	work := []int{target}
+	use := func(key string) {
+		if i, ok := defs[key]; ok && !need[i] {
+			need[i] = true
+			work = append(work, i)
+		}
+	}
+	for len(work) > 0 {
+		i := work[len(work)-1]
+		work = work[:len(work)-1]
+		Preorder(minDecl(prog.Decls[i], i == target), func(x Syntax) {
+			switch x := x.(type) {
+			case *Type:
+				if x.Kind == TypedefType && x.Name != nil {
+					use("typedef " + x.Name.String())
+				}
+				if x.Tag != nil {
+					use("tag " + x.Tag.String())
+				}
+			case *Expr:
+				if x.Op == Name {
+					use(x.Text.String())
+				}
+			}
+		})
+	}
+	var keep []int
+	for i := range need {
+		keep = append(keep, i)
+	}
+	sort.Ints(keep)
+
+	parts := prelude
+	for _, i := range keep {
+		d := minDecl(prog.Decls[i], i == target)
+		if t := d.Type; d.Storage&Typedef != 0 && t.Tag != nil && t.Decls != nil {
+			// The printer spells a tagged type by its tag alone,
+			// so define the tag ahead of the typedef.
+			parts = append(parts, fixtureDecl(&Decl{Type: t}))
+		}
+		parts = append(parts, fixtureDecl(d))
+	}
+	return strings.Join(parts, "\n") + "\n"
+}
+
+// minDecl returns d as it appears in a minimized source: functions
+// other than the target lose their bodies.
+func minDecl(d *Decl, target bool) *Decl {
+	if target || d.Body == nil {
+		return d
+	}
+	proto := *d
+	proto.Body = nil
+	return &proto
+}
+
+// reproduces reports whether infos contain finding. A finding without
+// a category, as returned by Casts, matches a cast of any category.
+func reproduces(infos []CastInfo, finding CastInfo) bool {
+	for _, info := range infos {
+		if finding.Category == "" {
+			info.Category = ""
+		}
+		if info.Fingerprint() == finding.Fingerprint() {
+			return true
+		}
+	}
+	return false
+}
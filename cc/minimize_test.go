@@ -0,0 +1,46 @@
+package cc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMinimize(t *testing.T) {
+	src := `
+typedef unsigned char byte;
+typedef struct Point { int x, y; } Point;
+enum { Scale = 4 };
+int unused(int n) { return n + 1; }
+int scale(int v) { return v * Scale; }
+byte low(Point *p) {
+	return (byte)scale(p->x);
+}
+float narrow(double d) { return (float)d; }`
+	prog := mustParse(t, src)
+	var finding CastInfo
+	for _, info := range ClassifyCasts(prog, nil) {
+		if info.Func == "low" {
+			finding = info
+		}
+	}
+	out, err := Minimize([]byte(src), finding)
+	if err != nil {
+		t.Fatal(err)
+	}
+	min := string(out)
+	for _, s := range []string{"low(Point *p)", "typedef unsigned char byte", "struct Point {", "int scale(int v);"} {
+		if !strings.Contains(min, s) {
+			t.Errorf("minimized source lacks %q:\n%s", s, min)
+		}
+	}
+	for _, s := range []string{"unused", "narrow", "Scale"} {
+		if strings.Contains(min, s) {
+			t.Errorf("minimized source contains %q:\n%s", s, min)
+		}
+	}
+
+	finding.To = "short"
+	if _, err := Minimize([]byte(src), finding); err == nil {
+		t.Errorf("Minimize of a finding not in the source succeeded")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"io"
 	"io/ioutil"
 	"strings"
+	"time"
 )
 
 func Read(name string, r io.Reader) (*Prog, error) {
@@ -43,6 +44,9 @@ func ReadMany(names []string, readers []io.Reader) (*Prog, error) {
 		} else {
 			prog.Span.End = lx.prog.Span.End
 			prog.Decls = append(prog.Decls, lx.prog.Decls...)
+			prog.Directives = append(prog.Directives, lx.prog.Directives...)
+			prog.files = append(prog.files, lx.prog.files...)
+			prog.profile = append(prog.profile, lx.prog.profile...)
 		}
 		lx.prog = nil
 		for sc := lx.scope; sc != nil; sc = sc.Next {
@@ -104,8 +108,32 @@ func ParseExpr(str string) (*Expr, error) {
 
 type Prog struct {
 	SyntaxInfo
-	Id    int
-	Decls []*Decl
+	Id         int
+	Decls      []*Decl
+	Directives []*Directive // preprocessor lines, in source order
+
+	files   []string     // names of the files parsed, excluding includes
+	profile []DeclTiming // see ParseProfile
+}
+
+// A DeclTiming is the time spent parsing a top-level declaration.
+type DeclTiming struct {
+	Decl     *Decl
+	Duration time.Duration
+}
+
+// ParseProfile returns the time spent parsing each top-level declaration
+// of x, in source order, including those from included files. It returns
+// nil unless x was parsed with ProfileParse(true).
+func (x *Prog) ParseProfile() []DeclTiming {
+	return x.profile
+}
+
+// A Directive is a preprocessor line, such as an #include or #define,
+// kept verbatim so that it can be printed back in place.
+type Directive struct {
+	Span Span
+	Text string // raw text, including the leading '#'
 }
 
 func (x *Prog) GetId() int {
@@ -119,6 +147,15 @@ func (x *Prog) String() string {
 	return p.String()
 }
 
+// Format returns the C source for x, with its preprocessor directives
+// re-emitted in their original position. Declarations that came from
+// included files are left out, since the #include lines bring them back.
+func (x *Prog) Format() string {
+	var p Printer
+	p.printFormat(x)
+	return p.String()
+}
+
 func (x *Prog) GetChildren() []Syntax {
 	lst := []Syntax{}
 	for _, elem := range x.Decls {
@@ -25,6 +25,11 @@ type Printer struct {
 	html         bool
 	suffix       []Comment // suffix comments to print at next newline
 	hideComments bool
+	hideCasts    bool // print explicit casts and parentheses as their operand
+
+	emit func(text string, role TokenRole) // set by FormatHighlighted
+	role TokenRole                         // token kind and cast flags in effect
+	tabs int                               // indentation not yet emitted
 }
 
 func (p *Printer) StartHTML() {
@@ -67,43 +72,39 @@ func (p *Printer) Print(args ...interface{}) {
 		default:
 			fmt.Fprintf(&p.buf, "(?%T)", arg)
 		case string:
-			if p.html {
-				htmlEscaper.WriteString(&p.buf, arg)
-			} else {
-				p.buf.WriteString(arg)
-			}
+			p.printToken(arg, -1)
 		case EmptyLiteral:
 			p.Print(arg.String())
 		case *EmptyLiteral:
 			p.Print(arg.String())
 		case BooleanLiteral:
-			p.Print(arg.String())
+			p.printToken(arg.String(), RoleLiteral)
 		case *BooleanLiteral:
-			p.Print(arg.String())
+			p.printToken(arg.String(), RoleLiteral)
 		case IntegerLiteral:
-			p.Print(arg.String())
+			p.printToken(arg.String(), RoleLiteral)
 		case *IntegerLiteral:
-			p.Print(arg.String())
+			p.printToken(arg.String(), RoleLiteral)
 		case CharLiteral:
-			p.Print(arg.String())
+			p.printToken(arg.String(), RoleLiteral)
 		case *CharLiteral:
-			p.Print(arg.String())
+			p.printToken(arg.String(), RoleLiteral)
 		case RealLiteral:
-			p.Print(arg.String())
+			p.printToken(arg.String(), RoleLiteral)
 		case *RealLiteral:
-			p.Print(arg.String())
+			p.printToken(arg.String(), RoleLiteral)
 		case StringLiteral:
-			p.Print(arg.String())
+			p.printToken(arg.String(), RoleLiteral)
 		case *StringLiteral:
-			p.Print(arg.String())
+			p.printToken(arg.String(), RoleLiteral)
 		case SymbolLiteral:
 			p.Print(arg.String())
 		case *SymbolLiteral:
 			p.Print(arg.String())
 		case LanguageKeyword:
-			p.Print(arg.String())
+			p.printToken(arg.String(), RoleKeyword)
 		case *LanguageKeyword:
-			p.Print(arg.String())
+			p.printToken(arg.String(), RoleKeyword)
 		case exprPrec:
 			p.printExpr(arg.expr, arg.prec)
 		case *Expr:
@@ -117,11 +118,15 @@ func (p *Printer) Print(args ...interface{}) {
 		case *Stmt:
 			p.printStmt(arg)
 		case *Type:
-			p.printType(arg, "")
+			p.withRole(RoleType, TypedName{arg, ""})
 		case *Decl:
 			p.printDecl(arg)
 		case TypedName:
-			p.printType(arg.Type, arg.Name)
+			if p.role.Kind() == RoleType {
+				p.printType(arg.Type, arg.Name)
+			} else {
+				p.withRole(RoleType, arg)
+			}
 		case Storage:
 			p.Print(arg.String())
 		case []Comment:
@@ -137,7 +142,8 @@ func (p *Printer) Print(args ...interface{}) {
 				p.suffix = append(p.suffix, com)
 			} else {
 				for _, line := range strings.Split(com.Text, "\n") {
-					p.Print(line, newline)
+					p.printToken(line, RoleComment)
+					p.Print(newline)
 				}
 			}
 		case nestBlock:
@@ -161,15 +167,19 @@ func (p *Printer) Print(args ...interface{}) {
 				b := p.buf.Bytes()
 				if len(b) > 0 && b[len(b)-1] == '\t' {
 					p.buf.Truncate(len(b) - 1)
+					if p.tabs > 0 {
+						p.tabs--
+					}
 				}
 			case newline:
 				for _, com := range p.suffix {
-					p.Print(" ", com.Text)
+					p.Print(" ")
+					p.printToken(com.Text, RoleComment)
 				}
 				p.suffix = p.suffix[:0]
-				p.buf.WriteString("\n")
+				p.printToken("\n", RoleSpace)
 				for i := 0; i < p.indent; i++ {
-					p.buf.WriteByte('\t')
+					p.printToken("\t", RoleSpace)
 				}
 			}
 		}
@@ -204,10 +214,12 @@ var opPrec = []int{
 	AndAnd:     precAndAnd,
 	AndEq:      precEq,
 	Arrow:      precArrow,
+	Builtin:    precArrow,
 	Call:       precArrow,
 	CUDACall:   precArrow,
 	Cast:       precAddr,
 	CastInit:   precAddr,
+	CxxCast:    precArrow,
 	Comma:      precComma,
 	Cond:       precCond,
 	Div:        precMul,
@@ -238,7 +250,7 @@ var opPrec = []int{
 	Or:         precOr,
 	OrEq:       precEq,
 	OrOr:       precOrOr,
-	Paren:      precLow,
+	Paren:      precNone,
 	Plus:       precAddr,
 	PostDec:    precAddr,
 	PostInc:    precAddr,
@@ -310,6 +322,11 @@ func (p *Printer) printExpr(x *Expr, prec int) {
 		defer fmt.Fprintf(&p.buf, "</span>")
 	}
 
+	if p.hideCasts && (x.isCast() || x.Op == Paren) {
+		p.printExpr(x.Left, prec)
+		return
+	}
+
 	p.Print(x.Comments.Before)
 	defer p.Print(x.Comments.Suffix, x.Comments.After)
 
@@ -357,6 +374,22 @@ func (p *Printer) printExpr(x *Expr, prec int) {
 	case Arrow:
 		p.Print(exprPrec{x.Left, prec}, "->", x.Text)
 
+	case Builtin:
+		p.Print(x.Text, "(")
+		for i, t := range x.TypeArgs {
+			if i > 0 {
+				p.Print(", ")
+			}
+			p.Print(t)
+		}
+		for i, y := range x.List {
+			if i > 0 || len(x.TypeArgs) > 0 {
+				p.Print(", ")
+			}
+			p.printExpr(y, precComma)
+		}
+		p.Print(")")
+
 	case Call:
 		p.Print(exprPrec{x.Left, precAddr}, "(")
 		for i, y := range x.List {
@@ -385,10 +418,19 @@ func (p *Printer) printExpr(x *Expr, prec int) {
 		p.Print(")")
 
 	case Cast:
-		p.Print("(", x.Type, ")", exprPrec{x.Left, prec})
+		p.withRole(RoleCastType, "(", x.Type, ")")
+		p.withRole(RoleCastOperand, exprPrec{x.Left, prec})
 
 	case CastInit:
-		p.Print("(", x.Type, ")", x.Init)
+		p.withRole(RoleCastType, "(", x.Type, ")")
+		p.withRole(RoleCastOperand, x.Init)
+
+	case CxxCast:
+		p.printToken(x.Text.String(), RoleKeyword)
+		p.withRole(RoleCastType, "<", x.Type, ">")
+		p.Print("(")
+		p.withRole(RoleCastOperand, exprPrec{x.Left, precLow})
+		p.Print(")")
 
 	case Comma:
 		for i, y := range x.List {
@@ -422,7 +464,7 @@ func (p *Printer) printExpr(x *Expr, prec int) {
 		p.Print("offsetof(", x.Type, ", ", exprPrec{x.Left, precComma}, ")")
 
 	case Paren:
-		p.Print("(", exprPrec{x.Left, prec}, ")")
+		p.Print("(", exprPrec{x.Left, precLow}, ")")
 
 	case PostDec:
 		p.Print(exprPrec{x.Left, prec}, "--")
@@ -495,6 +537,43 @@ func (p *Printer) printProg(x *Prog) {
 	}
 }
 
+func (p *Printer) printFormat(x *Prog) {
+	p.Print(x.Comments.Before)
+	defer p.Print(x.Comments.Suffix, x.Comments.After)
+
+	file := map[string]int{}
+	for i, name := range x.files {
+		file[name] = i + 1
+	}
+	// before reports whether a precedes b in the parsed files.
+	before := func(a, b Pos) bool {
+		if file[a.File] != file[b.File] {
+			return file[a.File] < file[b.File]
+		}
+		return a.Line < b.Line
+	}
+
+	dirs := x.Directives
+	for _, decl := range x.Decls {
+		pos := decl.Span.Start
+		if file[pos.File] == 0 {
+			continue
+		}
+		for len(dirs) > 0 && before(dirs[0].Span.Start, pos) {
+			p.Print(dirs[0].Text, newline)
+			dirs = dirs[1:]
+		}
+		p.Print(decl)
+		if decl.Body == nil {
+			p.Print(";")
+		}
+		p.Print(newline)
+	}
+	for _, dir := range dirs {
+		p.Print(dir.Text, newline)
+	}
+}
+
 func (p *Printer) printStmt(x *Stmt) {
 	if len(x.Labels) > 0 {
 		p.Print(untab, unindent, x.Comments.Before, indent, "\t")
@@ -590,14 +669,23 @@ func (p *Printer) printType(x *Type, name string) {
 
 	switch x.Kind {
 	case Ptr:
+		if x.Qual != 0 {
+			if name != "" {
+				name = " " + name
+			}
+			name = x.Qual.String() + name
+		}
 		p.printType(x.Base, "*"+name)
 	case Array:
 		if strings.HasPrefix(name, "*") {
 			name = "(" + name + ")"
 		}
-		if x.Width == nil {
+		switch {
+		case x.Width == nil:
 			p.printType(x.Base, name+"[]")
-		} else {
+		case x.Static:
+			p.printType(x.Base, name+"[static "+x.Width.String()+"]")
+		default:
 			p.printType(x.Base, name+"["+x.Width.String()+"]")
 		}
 	case Func:
@@ -616,7 +704,16 @@ func (p *Printer) printType(x *Type, name string) {
 		p.printType(x.Base, pp.String())
 
 	default:
-		p.Print(x.String())
+		if x.Qual != 0 {
+			p.Print(x.Qual.String(), " ")
+		}
+		if int(x.Kind) < len(typeKindSpelling) && typeKindSpelling[x.Kind] != "" {
+			p.Print(typeKindSpelling[x.Kind])
+		} else {
+			u := *x
+			u.Qual = 0
+			p.Print(u.String())
+		}
 		i := 0
 		for i < len(name) && name[i] == '*' {
 			i++
@@ -624,7 +721,10 @@ func (p *Printer) printType(x *Type, name string) {
 		if i < len(name) && name[i] != '\n' {
 			p.Print(" ")
 		}
+		role := p.role
+		p.role &^= roleKinds // the declarator is not part of the type
 		p.Print(name)
+		p.role = role
 	}
 }
 
@@ -638,17 +738,24 @@ func (p *Printer) printDecl(x *Decl) {
 	if x.Type == nil {
 		p.Print(x.Name)
 	} else {
-		name := x.Name.String()
+		name := declName(x)
 		if x.Type.Kind == Func && x.Body != nil {
 			name = "\n" + name
 		}
 		p.Print(TypedName{x.Type, name})
-		if x.Name.String() == "" {
+		if declName(x) == "" {
 			switch x.Type.Kind {
 			case Struct, Union, Enum:
+				if x.Type.Kind == Enum && x.Type.Base != nil {
+					p.Print(" : ", x.Type.Base)
+				}
 				p.Print(" {", indent)
+				sep := ";"
+				if x.Type.Kind == Enum {
+					sep = ","
+				}
 				for _, decl := range x.Type.Decls {
-					p.Print(newline, decl)
+					p.Print(newline, decl, sep)
 				}
 				p.Print(unindent, newline, "}")
 			}
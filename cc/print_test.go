@@ -4,7 +4,10 @@
 
 package cc
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 var exprTests = []string{
 	"x",
@@ -86,3 +89,191 @@ func XTestPrintExpr(t *testing.T) {
 		}
 	}
 }
+
+func TestPrintBuiltin(t *testing.T) {
+	str := "__builtin_types_compatible_p(int, long)"
+	x, err := ParseExpr(str)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if out := x.String(); out != str {
+		t.Errorf("ParseExpr(%#q).String() = %#q, want original input", str, out)
+	}
+	if x.Op != Builtin || len(x.TypeArgs) != 2 {
+		t.Fatalf("ParseExpr(%#q) = %v with %d type arguments, want Builtin with 2", str, x.Op, len(x.TypeArgs))
+	}
+	var types []*Type
+	Preorder(x, func(x Syntax) {
+		if t, ok := x.(*Type); ok {
+			types = append(types, t)
+		}
+	})
+	if len(types) != 2 || types[0] != x.TypeArgs[0] || types[1] != x.TypeArgs[1] {
+		t.Errorf("Preorder visited types %v, want the type arguments %v", types, x.TypeArgs)
+	}
+}
+
+func TestParseBuiltins(t *testing.T) {
+	_, err := ParseProg(`
+struct S { int a; int b; };
+long f(int x) {
+	long n = __builtin_offsetof(struct S, b);
+	return __builtin_choose_expr(__builtin_types_compatible_p(int, long), (long)x, n);
+}`)
+	if err != nil {
+		t.Errorf("%v", err)
+	}
+}
+
+func TestFormatDirectives(t *testing.T) {
+	src := `#include <wb.h>
+#define N 4
+int a[N];
+#define TWICE(x) \
+	((x) * 2)
+int
+f(int x)
+{
+	return (int)x * N;
+}
+`
+	prog, err := ParseProg(src)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	out := prog.Format()
+	if out != src {
+		t.Errorf("Format() = %#q, want original input %#q", out, src)
+	}
+	prog, err = ParseProg(out)
+	if err != nil {
+		t.Fatalf("reparsing Format output: %v", err)
+	}
+	if len(prog.Directives) != 3 {
+		t.Errorf("reparsed program has %d directives, want 3", len(prog.Directives))
+	}
+}
+
+func TestParseMultipleDeclarators(t *testing.T) {
+	prog := mustParse(t, `
+int *a, b, (*c)(void);
+void f(void) {
+}`)
+	want := []string{"int*", "int", "int (*)(void)", "void (void)"}
+	if len(prog.Decls) != len(want) {
+		t.Fatalf("parsed %d decls, want %d", len(prog.Decls), len(want))
+	}
+	for i, d := range prog.Decls {
+		if got := d.Type.Spelling(); got != want[i] {
+			t.Errorf("type of %s = %q, want %q", d.Name, got, want[i])
+		}
+	}
+}
+
+func TestParseProfile(t *testing.T) {
+	src := `
+int a, b;
+struct S { int x; };
+int f(int x) {
+	return (int)x;
+}`
+	if prof := mustParse(t, src).ParseProfile(); prof != nil {
+		t.Errorf("ParseProfile() = %v with profiling disabled, want nil", prof)
+	}
+	ProfileParse(true)
+	defer ProfileParse(false)
+	prog := mustParse(t, src)
+	prof := prog.ParseProfile()
+	if len(prof) != len(prog.Decls) {
+		t.Fatalf("ParseProfile() has %d timings, want one per decl (%d)", len(prof), len(prog.Decls))
+	}
+	for i, timing := range prof {
+		if timing.Decl != prog.Decls[i] {
+			t.Errorf("timing %d is for %v, want %v", i, timing.Decl, prog.Decls[i])
+		}
+		if timing.Duration < 0 {
+			t.Errorf("timing for %v is negative: %v", timing.Decl, timing.Duration)
+		}
+	}
+}
+
+func TestAtomicRoundTrip(t *testing.T) {
+	src := `_Atomic int counter;
+_Thread_local int scratch;
+_Atomic(long) total;
+int
+f(_Atomic int *p)
+{
+	return *(int*)p + counter;
+}
+`
+	prog := mustParse(t, src)
+	want := strings.Replace(src, "_Atomic(long)", "_Atomic long", 1)
+	if out := prog.Format(); out != want {
+		t.Errorf("Format() = %#q, want %#q", out, want)
+	}
+	counter := prog.Decls[0]
+	if counter.Type.Qual&Atomic == 0 || counter.Type.Equal(IntType) {
+		t.Errorf("type of counter = %v, want _Atomic int", counter.Type)
+	}
+	if !prog.Decls[2].Type.Equal(qualify(LongType, Atomic)) {
+		t.Errorf("type of total = %v, want _Atomic long", prog.Decls[2].Type)
+	}
+	if prog.Decls[1].Storage&ThreadLocal == 0 {
+		t.Errorf("storage of scratch = %v, want _Thread_local", prog.Decls[1].Storage)
+	}
+	casts := Casts(prog)
+	if len(casts) != 1 || !casts[0].Expr.DropsAtomic() {
+		t.Errorf("(int*)p does not drop _Atomic")
+	}
+	if casts[0].From != "_Atomic int*" {
+		t.Errorf("(int*)p converts from %q, want %q", casts[0].From, "_Atomic int*")
+	}
+}
+
+func TestFormatHighlighted(t *testing.T) {
+	x, err := ParseExpr("(unsigned char)x + 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	type token struct {
+		text string
+		role TokenRole
+	}
+	var got []token
+	FormatHighlighted(x, func(text string, role TokenRole) {
+		got = append(got, token{text, role})
+	})
+	want := []token{
+		{"(", RoleOperator | RoleCastType},
+		{"unsigned", RoleType | RoleCastType},
+		{" ", RoleSpace | RoleCastType},
+		{"char", RoleType | RoleCastType},
+		{")", RoleOperator | RoleCastType},
+		{"x", RoleIdentifier | RoleCastOperand},
+		{" ", RoleSpace},
+		{"+", RoleOperator},
+		{" ", RoleSpace},
+		{"1", RoleLiteral},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("FormatHighlighted emitted %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("token %d = %q %v, want %q %v", i, got[i].text, got[i].role, want[i].text, want[i].role)
+		}
+	}
+
+	prog := mustParse(t, "int\nf(int n)\n{\n\t// sum\n\treturn sizeof(n);\n}\n")
+	var text string
+	FormatHighlighted(prog, func(s string, role TokenRole) {
+		text += s
+		if s == "sizeof" && role != RoleKeyword {
+			t.Errorf("sizeof emitted as %v, want Keyword", role)
+		}
+	})
+	if text != prog.Format() {
+		t.Errorf("FormatHighlighted text = %#q, want %#q", text, prog.Format())
+	}
+}
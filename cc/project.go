@@ -0,0 +1,157 @@
+package cc
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// DiffProjects compares the C and CUDA sources in two directory trees.
+// Files are paired by their path relative to oldRoot and newRoot, and
+// each pair is compared as by Diff with opts, with file names reported
+// relative to the roots, or to opts.PathRoot if it is set; a file
+// present on one side only has all its casts added or removed. Pairs
+// are compared concurrently, but opts.Progress is called from the
+// calling goroutine. The changes are returned in file order.
+func DiffProjects(oldRoot, newRoot string, opts DiffOptions) ([]CastChange, error) {
+	oldFiles, err := sourceFiles(oldRoot)
+	if err != nil {
+		return nil, err
+	}
+	newFiles, err := sourceFiles(newRoot)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for f := range oldFiles {
+		files = append(files, f)
+	}
+	for f := range newFiles {
+		if !oldFiles[f] {
+			files = append(files, f)
+		}
+	}
+	sort.Strings(files)
+
+	// Pair files by their names relative to the roots, whatever
+	// opts.PathRoot says; the reported names are rewritten afterwards.
+	pairOpts := opts
+	pairOpts.PathRoot = ""
+	pairOpts.SingleFile = false
+	normOld := DiffOptions{PathRoot: oldRoot}.normCasts
+	normNew := DiffOptions{PathRoot: newRoot}.normCasts
+
+	type result struct {
+		i       int
+		changes []CastChange
+		err     error
+	}
+	jobs := make(chan int)
+	results := make(chan result)
+	for w := 0; w < runtime.GOMAXPROCS(0); w++ {
+		go func() {
+			for i := range jobs {
+				var r result
+				r.i = i
+				old, err := projectProg(oldRoot, files[i], oldFiles[files[i]])
+				if err != nil {
+					r.err = err
+					results <- r
+					continue
+				}
+				new, err := projectProg(newRoot, files[i], newFiles[files[i]])
+				if err != nil {
+					r.err = err
+					results <- r
+					continue
+				}
+				r.changes = pairOpts.diffProgs(old, new, normOld, normNew)
+				results <- r
+			}
+		}()
+	}
+	go func() {
+		for i := range files {
+			jobs <- i
+		}
+		close(jobs)
+	}()
+
+	perFile := make([][]CastChange, len(files))
+	var firstErr error
+	for done := 1; done <= len(files); done++ {
+		r := <-results
+		if r.err != nil && firstErr == nil {
+			firstErr = r.err
+		}
+		perFile[r.i] = r.changes
+		if opts.Progress != nil {
+			opts.Progress(done, len(files))
+		}
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	var changes []CastChange
+	for _, c := range perFile {
+		changes = append(changes, c...)
+	}
+	if opts.PathRoot != "" {
+		for _, c := range changes {
+			if c.Old != nil {
+				opts.rebase(c.Old, oldRoot)
+			}
+			if c.New != nil {
+				opts.rebase(c.New, newRoot)
+			}
+		}
+	}
+	return changes, nil
+}
+
+// rebase rewrites the file names of info, relative to root, as
+// opts.PathRoot makes them.
+func (opts DiffOptions) rebase(info *CastInfo, root string) {
+	for _, p := range []*Pos{&info.Span.Start, &info.Span.End} {
+		if p.File != "" {
+			p.File = opts.normPath(filepath.Join(root, p.File))
+		}
+	}
+}
+
+// sourceFiles returns the C and CUDA source files under root, by path
+// relative to root.
+func sourceFiles(root string) (map[string]bool, error) {
+	files := map[string]bool{}
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		switch filepath.Ext(path) {
+		case ".c", ".cu":
+			if !info.IsDir() {
+				rel, err := filepath.Rel(root, path)
+				if err != nil {
+					return err
+				}
+				files[rel] = true
+			}
+		}
+		return nil
+	})
+	return files, err
+}
+
+// parseMu serializes parsing, which uses package-level state.
+var parseMu sync.Mutex
+
+// projectProg parses the file rel under root, or returns nil if the
+// file is not present.
+func projectProg(root, rel string, present bool) (*Prog, error) {
+	if !present {
+		return nil, nil
+	}
+	return parseFile(filepath.Join(root, rel))
+}
@@ -0,0 +1,66 @@
+package cc
+
+import "sort"
+
+// Reconcile merges the findings for one file reported from two views of
+// it: ccInfos, found by this package in the parsed source, and astInfos,
+// found in an AST produced by another front end such as clang, whose
+// casts carry no *Expr but include the implicit conversions this parser
+// cannot see. Findings are matched by the file, line and column of the
+// start of their span; byte offsets, which another front end may not
+// report, are not compared. Where
+// both views report a cast, the cc findings are kept for their source
+// spelling, with any type spelling they lack filled in from the other
+// view; casts reported only in astInfos are added. The result is sorted
+// by position.
+func Reconcile(ccInfos, astInfos []CastInfo) []CastInfo {
+	ast := map[srcLoc]CastInfo{}
+	for _, info := range astInfos {
+		if _, ok := ast[locOf(info)]; !ok {
+			ast[locOf(info)] = info
+		}
+	}
+	seen := map[srcLoc]bool{}
+	var infos []CastInfo
+	for _, info := range ccInfos {
+		seen[locOf(info)] = true
+		if other, ok := ast[locOf(info)]; ok {
+			if info.From == "" {
+				info.From = other.From
+			}
+			if info.To == "" {
+				info.To = other.To
+			}
+		}
+		infos = append(infos, info)
+	}
+	for _, info := range astInfos {
+		if !seen[locOf(info)] {
+			infos = append(infos, info)
+		}
+	}
+	sort.SliceStable(infos, func(i, j int) bool {
+		a, b := infos[i].Span.Start, infos[j].Span.Start
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Col < b.Col
+	})
+	return infos
+}
+
+// A srcLoc is the file, line and column of a position, by which
+// Reconcile matches findings.
+type srcLoc struct {
+	File      string
+	Line, Col int
+}
+
+// locOf returns the srcLoc of the start of info.
+func locOf(info CastInfo) srcLoc {
+	p := info.Span.Start
+	return srcLoc{p.File, p.Line, p.Col}
+}
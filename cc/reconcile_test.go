@@ -0,0 +1,70 @@
+package cc
+
+import "testing"
+
+func TestReconcile(t *testing.T) {
+	at := func(b int) Span {
+		p := Pos{File: "a.c", Line: 1, Byte: b, Col: b + 1}
+		return Span{Start: p, End: p}
+	}
+	ccInfos := []CastInfo{
+		{Span: at(10), To: "int", Category: PlainCast},
+		{Span: at(30), From: "double", To: "float", Category: FloatNarrowing},
+	}
+	astInfos := []CastInfo{
+		{Span: at(30), From: "double", To: "float", Category: PlainCast},
+		{Span: at(20), From: "char", To: "int", Category: PlainCast},
+		{Span: at(10), From: "long", To: "int", Category: PlainCast},
+	}
+	infos := Reconcile(ccInfos, astInfos)
+	want := []struct {
+		byte     int
+		from     string
+		category CastCategory
+	}{
+		{10, "long", PlainCast},
+		{20, "char", PlainCast},
+		{30, "double", FloatNarrowing},
+	}
+	if len(infos) != len(want) {
+		t.Fatalf("Reconcile returned %d findings, want %d: %+v", len(infos), len(want), infos)
+	}
+	for i, w := range want {
+		got := infos[i]
+		if got.Span.Start.Byte != w.byte || got.From != w.from || got.Category != w.category {
+			t.Errorf("finding %d = %s from %q at byte %d, want %s from %q at byte %d", i, got.Category, got.From, got.Span.Start.Byte, w.category, w.from, w.byte)
+		}
+	}
+}
+
+func TestReconcileParsed(t *testing.T) {
+	prog := mustParse(t, `#include <stdint.h>
+int32_t f(double d, char c) {
+	int x = c;
+	return (int32_t)d + x;
+}`)
+	ccInfos := Casts(prog)
+	if len(ccInfos) != 1 {
+		t.Fatalf("Casts = %+v, want one cast", ccInfos)
+	}
+	// Another front end reports lines and columns but no byte offsets.
+	at := func(line, col int) Span {
+		p := Pos{File: "<string>", Line: line, Col: col}
+		return Span{Start: p, End: p}
+	}
+	cast := ccInfos[0].Span.Start
+	astInfos := []CastInfo{
+		{Span: at(cast.Line, cast.Col), From: "double", To: "int32_t", Category: PlainCast},
+		{Span: at(3, 10), From: "char", To: "int", Category: PlainCast},
+	}
+	infos := Reconcile(ccInfos, astInfos)
+	if len(infos) != 2 {
+		t.Fatalf("Reconcile returned %d findings, want 2: %+v", len(infos), infos)
+	}
+	if infos[0].Span.Start.Line != 3 || infos[0].From != "char" {
+		t.Errorf("first finding = %+v, want the implicit conversion of c", infos[0])
+	}
+	if infos[1].Expr == nil || infos[1].From != "double" {
+		t.Errorf("second finding = %+v, want the cc cast with the type filled in", infos[1])
+	}
+}
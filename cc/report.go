@@ -0,0 +1,95 @@
+package cc
+
+import "strings"
+
+// Coalesce merges findings that share a fingerprint and a source span,
+// as happens when one macro expands to the same cast many times or one
+// header is read into several programs. Ordinals are not compared, as
+// the copies of a cast may be numbered differently where each is found.
+// Each returned finding records in Count how many findings it stands
+// for. The order of first occurrence is preserved.
+func Coalesce(infos []CastInfo) []CastInfo {
+	type key struct {
+		fp   string
+		span Span
+	}
+	var out []CastInfo
+	index := map[key]int{}
+	for _, info := range infos {
+		unnumbered := info
+		unnumbered.Ordinal = 0
+		k := key{unnumbered.Fingerprint(), info.Span}
+		if i, ok := index[k]; ok {
+			out[i].Count++
+			continue
+		}
+		index[k] = len(out)
+		info.Count = 1
+		out = append(out, info)
+	}
+	return out
+}
+
+// GroupByTypePair groups findings by the types they convert between,
+// keyed by the canonical spellings of the source and target types, so
+// that systemic conversions such as int to char stand out. Within a
+// group the order of infos is preserved.
+func GroupByTypePair(infos []CastInfo) map[[2]string][]CastInfo {
+	groups := map[[2]string][]CastInfo{}
+	for _, info := range infos {
+		k := [2]string{canonicalType(info.From), canonicalType(info.To)}
+		groups[k] = append(groups[k], info)
+	}
+	return groups
+}
+
+// canonicalType respells the type spelled s as Spelling would, so that
+// spellings from other tools, such as "unsigned char *", compare equal
+// to ours. Spellings that do not parse, for example because they use a
+// typedef name, only have their white space normalized.
+func canonicalType(s string) string {
+	if x, err := ParseExpr("(" + s + ")0"); err == nil && x.Op == Cast {
+		return x.Type.Spelling()
+	}
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// A RunDelta compares two analyses of the same file, such as before and
+// after a fix.
+type RunDelta struct {
+	Resolved   []CastInfo `json:"resolved"`   // findings of the first run only
+	New        []CastInfo `json:"new"`        // findings of the second run only
+	Persisting []CastInfo `json:"persisting"` // findings of both runs, as reported by the second
+}
+
+// DeltaRuns compares the findings of two runs on the same file by
+// fingerprint, so that findings that merely moved are neither resolved
+// nor new. Findings sharing a fingerprint are matched in order; the
+// order of each run is preserved within each list.
+func DeltaRuns(before, after []CastInfo) RunDelta {
+	var d RunDelta
+	inAfter := map[string]int{}
+	for _, info := range after {
+		inAfter[info.Fingerprint()]++
+	}
+	for _, info := range before {
+		if fp := info.Fingerprint(); inAfter[fp] > 0 {
+			inAfter[fp]--
+		} else {
+			d.Resolved = append(d.Resolved, info)
+		}
+	}
+	inBefore := map[string]int{}
+	for _, info := range before {
+		inBefore[info.Fingerprint()]++
+	}
+	for _, info := range after {
+		if fp := info.Fingerprint(); inBefore[fp] > 0 {
+			inBefore[fp]--
+			d.Persisting = append(d.Persisting, info)
+		} else {
+			d.New = append(d.New, info)
+		}
+	}
+	return d
+}
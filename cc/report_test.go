@@ -0,0 +1,128 @@
+package cc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCoalesce(t *testing.T) {
+	dir, err := ioutil.TempDir("", "castdiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "lo.h"), []byte("static const char lo = (char)300;\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	read := func(name, src string) []CastInfo {
+		prog, err := Read(filepath.Join(dir, name), strings.NewReader(src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return Casts(prog)
+	}
+	// The cast in lo.h is the second global cast from int to char in
+	// a.c but the first in b.c.
+	a := read("a.c", "static const char hi = (char)1;\n#include \"lo.h\"\n")
+	b := read("b.c", "#include \"lo.h\"\n")
+	if len(a) != 2 || len(b) != 1 || a[1].Ordinal == b[0].Ordinal {
+		t.Fatalf("casts of a.c = %+v, of b.c = %+v, want the cast in lo.h numbered apart", a, b)
+	}
+	infos := Coalesce(append(a, b...))
+	if len(infos) != 2 {
+		t.Fatalf("Coalesce returned %d findings, want 2: %+v", len(infos), infos)
+	}
+	if infos[0].Span != a[0].Span || infos[0].Count != 1 {
+		t.Errorf("first finding at %v has count %d, want %v with count 1", infos[0].Span, infos[0].Count, a[0].Span)
+	}
+	if infos[1].Span != b[0].Span || infos[1].Count != 2 {
+		t.Errorf("second finding at %v has count %d, want %v with count 2", infos[1].Span, infos[1].Count, b[0].Span)
+	}
+}
+
+func TestFingerprintStable(t *testing.T) {
+	before := mustParse(t, `
+int f(double d, double e) {
+	return (int)d + (int)e;
+}`)
+	after := mustParse(t, `
+// a comment and a new function push f down
+static int unused;
+long g(int x) { return (long)x; }
+
+int
+f(double d, double e)
+{
+	int sum;
+	sum = (int)d;
+	return sum + (int)e;
+}`)
+	var a, b []string
+	for _, info := range ClassifyCasts(before, nil) {
+		a = append(a, info.Fingerprint())
+	}
+	for _, info := range ClassifyCasts(after, nil) {
+		if info.Func == "f" {
+			b = append(b, info.Fingerprint())
+		}
+	}
+	if len(a) != 2 || len(b) != 2 {
+		t.Fatalf("found %d and %d casts in f, want 2", len(a), len(b))
+	}
+	if a[0] == a[1] {
+		t.Errorf("two casts of the same shape share fingerprint %q", a[0])
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Errorf("fingerprint %d changed from %q to %q", i, a[i], b[i])
+		}
+	}
+}
+
+func TestGroupByTypePair(t *testing.T) {
+	infos := []CastInfo{
+		{Func: "f", From: "int", To: "char"},
+		{Func: "g", From: "double", To: "unsigned char*"},
+		{Func: "h", From: "int", To: "char"},
+		{Func: "k", From: "double", To: "unsigned  char *"},
+		{Func: "m", From: "int", To: "char"},
+	}
+	groups := GroupByTypePair(infos)
+	if len(groups) != 2 {
+		t.Fatalf("GroupByTypePair returned %d groups, want 2: %v", len(groups), groups)
+	}
+	ints := groups[[2]string{"int", "char"}]
+	if len(ints) != 3 || ints[0].Func != "f" || ints[2].Func != "m" {
+		t.Errorf("int to char group = %+v, want f, h and m in order", ints)
+	}
+	if n := len(groups[[2]string{"double", "unsigned char*"}]); n != 2 {
+		t.Errorf("double to unsigned char* group has %d findings, want 2", n)
+	}
+}
+
+func TestDeltaRuns(t *testing.T) {
+	before := mustParse(t, `
+float f(double d, int n) {
+	float x = (float)d;
+	return (float)n;
+}`)
+	after := mustParse(t, `
+// moved down by a comment
+float f(double d, int n) {
+	long y = (long)n;
+	return (float)n;
+}`)
+	d := DeltaRuns(ClassifyCasts(before, nil), ClassifyCasts(after, nil))
+	if len(d.Resolved) != 1 || d.Resolved[0].Expr.String() != "(float)d" {
+		t.Errorf("resolved = %+v, want (float)d", d.Resolved)
+	}
+	if len(d.New) != 1 || d.New[0].Expr.String() != "(long)n" {
+		t.Errorf("new = %+v, want (long)n", d.New)
+	}
+	if len(d.Persisting) != 1 || d.Persisting[0].Span.Start.Line != 5 {
+		t.Errorf("persisting = %+v, want (float)n on line 5", d.Persisting)
+	}
+}
@@ -0,0 +1,119 @@
+package cc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// DefaultReviewBatch is the number of comments posted per request when
+// ReviewConfig.BatchSize is zero.
+const DefaultReviewBatch = 50
+
+// ReviewConfig says where PostReviewComments sends its comments.
+type ReviewConfig struct {
+	// URL is the endpoint the comments are posted to.
+	URL string
+
+	// Token, if set, is sent as a bearer token in the Authorization
+	// header of every request.
+	Token string
+
+	// BatchSize is the largest number of comments sent in one request.
+	// Zero means DefaultReviewBatch.
+	BatchSize int
+
+	// Client sends the requests. Nil means http.DefaultClient.
+	Client *http.Client
+}
+
+// A ReviewComment is a finding as posted to a code review: a comment on
+// one line of a file.
+type ReviewComment struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	Body string `json:"body"`
+}
+
+// reviewPayload is the body of one request made by PostReviewComments.
+type reviewPayload struct {
+	Comments []ReviewComment `json:"comments"`
+}
+
+// PostReviewComments posts infos as review comments to cfg.URL, at most
+// cfg.BatchSize to a request, as JSON objects of the form
+// {"comments": [{"path": ..., "line": ..., "body": ...}]}. It stops at
+// the first request that fails or is answered with a status other than
+// 2xx.
+func PostReviewComments(ctx context.Context, infos []CastInfo, cfg ReviewConfig) error {
+	batch := cfg.BatchSize
+	if batch <= 0 {
+		batch = DefaultReviewBatch
+	}
+	for len(infos) > 0 {
+		n := batch
+		if n > len(infos) {
+			n = len(infos)
+		}
+		var payload reviewPayload
+		for _, info := range infos[:n] {
+			payload.Comments = append(payload.Comments, reviewComment(info))
+		}
+		if err := cfg.post(ctx, payload); err != nil {
+			return err
+		}
+		infos = infos[n:]
+	}
+	return nil
+}
+
+// reviewComment returns the comment posted for info.
+func reviewComment(info CastInfo) ReviewComment {
+	var body bytes.Buffer
+	if info.Category != "" {
+		fmt.Fprintf(&body, "**%s** (%s): %s", info.Category, info.Category.Severity(), info.Category.Explain(info))
+	} else {
+		fmt.Fprintf(&body, "cast from %s to %s", info.From, info.To)
+	}
+	if info.Expr != nil {
+		fmt.Fprintf(&body, "\n\n`%s`", info.Expr)
+	}
+	if info.Suggestion != "" {
+		fmt.Fprintf(&body, "\n\nConsider: %s", info.Suggestion)
+	}
+	return ReviewComment{Path: info.Span.Start.File, Line: info.Span.Start.Line, Body: body.String()}
+}
+
+// post sends one batch of comments.
+func (cfg ReviewConfig) post(ctx context.Context, payload reviewPayload) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", cfg.URL, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	if cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+cfg.Token)
+	}
+	client := cfg.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("posting review comments: %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
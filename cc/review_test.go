@@ -0,0 +1,50 @@
+package cc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPostReviewComments(t *testing.T) {
+	infos := castsIn(t, `void f(double d) { float x = (float)d; }`)
+	var got []reviewPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer secret" {
+			t.Errorf("Authorization = %q, want %q", auth, "Bearer secret")
+		}
+		var p reviewPayload
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			t.Errorf("decoding payload: %v", err)
+		}
+		got = append(got, p)
+	}))
+	defer srv.Close()
+
+	cfg := ReviewConfig{URL: srv.URL, Token: "secret", Client: srv.Client()}
+	if err := PostReviewComments(context.Background(), infos, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || len(got[0].Comments) != 1 {
+		t.Fatalf("posted %+v, want one request with one comment", got)
+	}
+	want := ReviewComment{
+		Path: "<string>",
+		Line: 1,
+		Body: "**FloatNarrowing** (warning): narrows 64-bit double to 32-bit float, losing precision\n\n`(float)d`",
+	}
+	if c := got[0].Comments[0]; c != want {
+		t.Errorf("comment = %+v, want %+v", c, want)
+	}
+
+	cfg.BatchSize = 1
+	got = nil
+	if err := PostReviewComments(context.Background(), append(infos, infos...), cfg); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Errorf("posted %d requests with BatchSize 1, want 2", len(got))
+	}
+}
@@ -0,0 +1,115 @@
+package cc
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// JSONSchema returns a JSON Schema (draft-07) describing the objects
+// castdiff writes as JSON: a CastChange or a CastInfo. The schema is
+// derived from the json tags of the Go types, so it cannot drift from
+// what encoding/json produces for them.
+func JSONSchema() []byte {
+	defs := map[string]interface{}{}
+	doc := map[string]interface{}{
+		"$schema":     "http://json-schema.org/draft-07/schema#",
+		"title":       "castdiff output",
+		"definitions": defs,
+		"anyOf": []interface{}{
+			schemaOf(reflect.TypeOf(CastChange{}), defs),
+			schemaOf(reflect.TypeOf(CastInfo{}), defs),
+		},
+	}
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Categories returns every category castdiff can report: PlainCast
+// first, the categories of ClassifyCasts in rule order, then those
+// assigned by Diff.
+func Categories() []CastCategory {
+	cats := []CastCategory{PlainCast}
+	seen := map[CastCategory]bool{PlainCast: true}
+	for _, r := range castRules {
+		if !seen[r.category] {
+			seen[r.category] = true
+			cats = append(cats, r.category)
+		}
+	}
+	return append(cats, CastOnChangedType)
+}
+
+var (
+	categoryType   = reflect.TypeOf(CastCategory(""))
+	changeKindType = reflect.TypeOf(ChangeKind(0))
+)
+
+// schemaOf returns the schema of values of type t. Struct types are
+// added to defs and referred to by name.
+func schemaOf(t reflect.Type, defs map[string]interface{}) map[string]interface{} {
+	switch t {
+	case categoryType:
+		var enum []string
+		for _, c := range Categories() {
+			enum = append(enum, string(c))
+		}
+		return map[string]interface{}{"type": "string", "enum": enum}
+	case changeKindType:
+		var enum []string
+		for k := Added; k <= Modified; k++ {
+			enum = append(enum, k.String())
+		}
+		return map[string]interface{}{"type": "string", "enum": enum}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaOf(t.Elem(), defs)
+	case reflect.Slice:
+		return map[string]interface{}{"type": "array", "items": schemaOf(t.Elem(), defs)}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Struct:
+		ref := map[string]interface{}{"$ref": "#/definitions/" + t.Name()}
+		if _, ok := defs[t.Name()]; ok {
+			return ref
+		}
+		def := map[string]interface{}{"type": "object", "additionalProperties": false}
+		defs[t.Name()] = def // before the fields, for recursive types
+		props := map[string]interface{}{}
+		required := []string{}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("json")
+			if f.PkgPath != "" || tag == "-" {
+				continue
+			}
+			name, opts := tag, ""
+			if i := strings.Index(tag, ","); i >= 0 {
+				name, opts = tag[:i], tag[i:]
+			}
+			if name == "" {
+				name = f.Name
+			}
+			props[name] = schemaOf(f.Type, defs)
+			if !strings.Contains(opts, ",omitempty") {
+				required = append(required, name)
+			}
+		}
+		def["properties"] = props
+		def["required"] = required
+		return ref
+	}
+	panic("JSONSchema: unsupported type " + t.String())
+}
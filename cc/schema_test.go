@@ -0,0 +1,111 @@
+package cc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestJSONSchema(t *testing.T) {
+	var schema map[string]interface{}
+	if err := json.Unmarshal(JSONSchema(), &schema); err != nil {
+		t.Fatalf("JSONSchema is not valid JSON: %v", err)
+	}
+
+	a := mustParse(t, `int f(double d) { return (int)d; }`)
+	b := mustParse(t, `float f(double d) { return (float)d; }`)
+	changes := Diff(a, b, DiffOptions{})
+	if len(changes) == 0 {
+		t.Fatal("Diff found no changes")
+	}
+	infos := ClassifyCasts(b, nil)
+	infos[0].Suggestion = "keep the double"
+	samples := []interface{}{changes[0], CastChange{Kind: Added, New: &infos[0], Confidence: 1}, infos[0]}
+	for _, sample := range samples {
+		data, err := json.Marshal(sample)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var v interface{}
+		if err := json.Unmarshal(data, &v); err != nil {
+			t.Fatal(err)
+		}
+		if err := validate(schema, schema, v); err != nil {
+			t.Errorf("%s does not match the schema: %v", data, err)
+		}
+	}
+
+	bad := map[string]interface{}{"kind": "Renamed", "confidence": 1.0}
+	if validate(schema, schema, bad) == nil {
+		t.Errorf("schema accepts a change of unknown kind")
+	}
+}
+
+// validate checks v against the subset of JSON Schema used by
+// JSONSchema, resolving references in root.
+func validate(root, s map[string]interface{}, v interface{}) error {
+	if ref, ok := s["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/definitions/")
+		def, ok := root["definitions"].(map[string]interface{})[name].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unresolved reference %s", ref)
+		}
+		return validate(root, def, v)
+	}
+	if any, ok := s["anyOf"].([]interface{}); ok {
+		var errs []string
+		for _, alt := range any {
+			err := validate(root, alt.(map[string]interface{}), v)
+			if err == nil {
+				return nil
+			}
+			errs = append(errs, err.Error())
+		}
+		return fmt.Errorf("no alternative matches: %s", strings.Join(errs, "; "))
+	}
+	if enum, ok := s["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			found = found || e == v
+		}
+		if !found {
+			return fmt.Errorf("%v not in %v", v, enum)
+		}
+	}
+	switch s["type"] {
+	case "string":
+		if _, ok := v.(string); !ok {
+			return fmt.Errorf("%v is not a string", v)
+		}
+	case "number":
+		if _, ok := v.(float64); !ok {
+			return fmt.Errorf("%v is not a number", v)
+		}
+	case "integer":
+		if f, ok := v.(float64); !ok || f != float64(int64(f)) {
+			return fmt.Errorf("%v is not an integer", v)
+		}
+	case "object":
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%v is not an object", v)
+		}
+		props := s["properties"].(map[string]interface{})
+		for _, r := range s["required"].([]interface{}) {
+			if _, ok := obj[r.(string)]; !ok {
+				return fmt.Errorf("missing property %s", r)
+			}
+		}
+		for k, fv := range obj {
+			ps, ok := props[k].(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("unexpected property %s", k)
+			}
+			if err := validate(root, ps, fv); err != nil {
+				return fmt.Errorf("%s: %v", k, err)
+			}
+		}
+	}
+	return nil
+}
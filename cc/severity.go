@@ -0,0 +1,88 @@
+package cc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A Severity ranks how likely a finding is to be a bug.
+type Severity int
+
+const (
+	Info    Severity = iota // worth knowing about, usually harmless
+	Warning                 // may lose information or defeat type checking
+	Error                   // almost certainly wrong
+)
+
+var severityString = []string{
+	Info:    "info",
+	Warning: "warning",
+	Error:   "error",
+}
+
+func (s Severity) String() string {
+	if 0 <= int(s) && int(s) < len(severityString) {
+		return severityString[s]
+	}
+	return fmt.Sprintf("Severity(%d)", s)
+}
+
+// ParseSeverity returns the severity named s, ignoring case.
+func ParseSeverity(s string) (Severity, error) {
+	for i, name := range severityString {
+		if strings.EqualFold(s, name) {
+			return Severity(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity %q", s)
+}
+
+var categorySeverity = map[CastCategory]Severity{
+	PlainCast:                Info,
+	FloatNarrowing:           Warning,
+	SizeofTruncation:         Warning,
+	ByteArithmeticCast:       Warning,
+	VectorReinterpretCast:    Error,
+	ImplicitDeclCast:         Error,
+	UncheckedDowncast:        Error,
+	ArrayDecayCast:           Warning,
+	EndianAssumptionCast:     Warning,
+	MaskTruncationCast:       Warning,
+	InductionVarCast:         Info,
+	GenericPointerCast:       Info,
+	SizeofOperandCast:        Warning,
+	HandleTypeConfusion:      Error,
+	TernaryCondCast:          Warning,
+	ShiftSignCast:            Warning,
+	EnumToBoolCast:           Warning,
+	UndersizedBufferCast:     Error,
+	CharIndexCast:            Warning,
+	EnumUnderlyingNarrowing:  Warning,
+	FunctionCastCall:         Error,
+	StringizedCast:           Warning,
+	SmartPointerEscapeCast:   Warning,
+	ArrayParamSizeCast:       Error,
+	LoopStrideCast:           Warning,
+	FloatBitPunCast:          Error,
+	IndexArithCast:           Warning,
+	NestedConstCast:          Warning,
+	OverflowSemanticCast:     Warning,
+	VolatileRoundTripCast:    Warning,
+	FixedWidthNarrowing:      Warning,
+	TwosComplementAssumption: Info,
+	WideningIndexCast:        Warning,
+	CommaCast:                Warning,
+	BoolToPointerCast:        Error,
+	LValueCast:               Warning,
+	FormatMismatchCast:       Error,
+	CastOnChangedType:        Error,
+}
+
+// Severity returns the severity of findings in category c.
+// Categories without an assigned severity are Warning.
+func (c CastCategory) Severity() Severity {
+	if s, ok := categorySeverity[c]; ok {
+		return s
+	}
+	return Warning
+}
@@ -148,3 +148,76 @@ var hdr_wb_h = `
 typedef int *wbArg_t;
 typedef int(*dim3)(int, int, int);
 `
+
+var hdr_vector_types_h = `
+typedef struct { char x; } char1;
+typedef struct { char x, y; } char2;
+typedef struct { char x, y, z; } char3;
+typedef struct { char x, y, z, w; } char4;
+typedef struct { unsigned char x; } uchar1;
+typedef struct { unsigned char x, y; } uchar2;
+typedef struct { unsigned char x, y, z; } uchar3;
+typedef struct { unsigned char x, y, z, w; } uchar4;
+typedef struct { short x; } short1;
+typedef struct { short x, y; } short2;
+typedef struct { short x, y, z; } short3;
+typedef struct { short x, y, z, w; } short4;
+typedef struct { unsigned short x; } ushort1;
+typedef struct { unsigned short x, y; } ushort2;
+typedef struct { unsigned short x, y, z; } ushort3;
+typedef struct { unsigned short x, y, z, w; } ushort4;
+typedef struct { int x; } int1;
+typedef struct { int x, y; } int2;
+typedef struct { int x, y, z; } int3;
+typedef struct { int x, y, z, w; } int4;
+typedef struct { unsigned int x; } uint1;
+typedef struct { unsigned int x, y; } uint2;
+typedef struct { unsigned int x, y, z; } uint3;
+typedef struct { unsigned int x, y, z, w; } uint4;
+typedef struct { long x; } long1;
+typedef struct { long x, y; } long2;
+typedef struct { long x, y, z; } long3;
+typedef struct { long x, y, z, w; } long4;
+typedef struct { unsigned long x; } ulong1;
+typedef struct { unsigned long x, y; } ulong2;
+typedef struct { unsigned long x, y, z; } ulong3;
+typedef struct { unsigned long x, y, z, w; } ulong4;
+typedef struct { long long x; } longlong1;
+typedef struct { long long x, y; } longlong2;
+typedef struct { long long x, y, z; } longlong3;
+typedef struct { long long x, y, z, w; } longlong4;
+typedef struct { unsigned long long x; } ulonglong1;
+typedef struct { unsigned long long x, y; } ulonglong2;
+typedef struct { unsigned long long x, y, z; } ulonglong3;
+typedef struct { unsigned long long x, y, z, w; } ulonglong4;
+typedef struct { float x; } float1;
+typedef struct { float x, y; } float2;
+typedef struct { float x, y, z; } float3;
+typedef struct { float x, y, z, w; } float4;
+typedef struct { double x; } double1;
+typedef struct { double x, y; } double2;
+typedef struct { double x, y, z; } double3;
+typedef struct { double x, y, z, w; } double4;
+`
+
+var hdr_stdbool_h = `
+typedef _Bool bool;
+enum { false, true };
+`
+
+// The fixed-width types are recognized by name, whatever the data
+// model makes of the types they are defined as here.
+var hdr_stdint_h = `
+typedef signed char int8_t;
+typedef short int16_t;
+typedef int int32_t;
+typedef long long int64_t;
+typedef unsigned char uint8_t;
+typedef unsigned short uint16_t;
+typedef unsigned int uint32_t;
+typedef unsigned long long uint64_t;
+typedef long intptr_t;
+typedef unsigned long uintptr_t;
+typedef long long intmax_t;
+typedef unsigned long long uintmax_t;
+`
@@ -90,6 +90,13 @@ func (x *Stmt) GetChildren() []Syntax {
 	return lst
 }
 
+func (x *Stmt) String() string {
+	var p Printer
+	p.hideComments = true
+	p.printStmt(x)
+	return p.String()
+}
+
 type StmtOp int
 
 const (
@@ -1,4 +1,4 @@
-package main
+package cc
 
 // Copy from https://github.com/arbovm/levenshtein
 // The Levenshtein distance between two strings is defined as the minimum
@@ -0,0 +1,96 @@
+package cc
+
+// A StructuralChange describes a declaration or statement that differs
+// between two programs in something other than its casts.
+type StructuralChange struct {
+	Kind ChangeKind
+	Func string // enclosing top-level declaration, "" for unnamed ones
+	Old  Syntax // declaration or statement in the old program, nil for Added
+	New  Syntax // declaration or statement in the new program, nil for Removed
+}
+
+// EqualIgnoringCasts reports whether x and y are the same syntax once
+// explicit casts, and the parentheses that often accompany them, are
+// looked through: (long)(a + b) is equal to a + b.
+func EqualIgnoringCasts(x, y Syntax) bool {
+	return castFree(x) == castFree(y)
+}
+
+// castFree returns the source of x with casts and parentheses elided.
+func castFree(x Syntax) string {
+	var p Printer
+	p.hideComments = true
+	p.hideCasts = true
+	p.Print(x)
+	return p.String()
+}
+
+// StructuralDiff compares a and b while treating casts as transparent,
+// answering whether anything besides casts changed. Top-level
+// declarations are aligned by name; the statements of matching function
+// bodies are aligned in order, and those that differ are reported.
+func StructuralDiff(a, b *Prog) []StructuralChange {
+	var changes []StructuralChange
+	old, new := a.Decls, b.Decls
+	align(len(old), len(new), func(i, j int) bool {
+		return declKey(old[i]) == declKey(new[j])
+	}, func(i, j int) {
+		switch {
+		case i < 0:
+			changes = append(changes, StructuralChange{Kind: Added, Func: declName(new[j]), New: new[j]})
+		case j < 0:
+			changes = append(changes, StructuralChange{Kind: Removed, Func: declName(old[i]), Old: old[i]})
+		case old[i].Body != nil && new[j].Body != nil && EqualIgnoringCasts(old[i].Type, new[j].Type):
+			changes = append(changes, diffStmts(declName(old[i]), old[i].Body.Block, new[j].Body.Block)...)
+		case !EqualIgnoringCasts(old[i], new[j]):
+			changes = append(changes, StructuralChange{Kind: Modified, Func: declName(old[i]), Old: old[i], New: new[j]})
+		}
+	})
+	return changes
+}
+
+// declKey identifies a top-level declaration across programs.
+func declKey(d *Decl) string {
+	if name := declName(d); name != "" {
+		return name
+	}
+	return castFree(d)
+}
+
+// diffStmts aligns the statements of a function body in two programs.
+// Differing statements left between aligned ones are paired in order.
+func diffStmts(fn string, old, new []*Stmt) []StructuralChange {
+	var changes []StructuralChange
+	var gapOld, gapNew []*Stmt
+	flush := func() {
+		for k := 0; k < len(gapOld) || k < len(gapNew); k++ {
+			c := StructuralChange{Kind: Modified, Func: fn}
+			if k < len(gapOld) {
+				c.Old = gapOld[k]
+			} else {
+				c.Kind = Added
+			}
+			if k < len(gapNew) {
+				c.New = gapNew[k]
+			} else {
+				c.Kind = Removed
+			}
+			changes = append(changes, c)
+		}
+		gapOld, gapNew = nil, nil
+	}
+	align(len(old), len(new), func(i, j int) bool {
+		return EqualIgnoringCasts(old[i], new[j])
+	}, func(i, j int) {
+		switch {
+		case i < 0:
+			gapNew = append(gapNew, new[j])
+		case j < 0:
+			gapOld = append(gapOld, old[i])
+		default:
+			flush()
+		}
+	})
+	flush()
+	return changes
+}
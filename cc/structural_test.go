@@ -0,0 +1,42 @@
+package cc
+
+import "testing"
+
+func TestStructuralDiff(t *testing.T) {
+	a := mustParse(t, `
+int g;
+long f(int x, double d) {
+	long r = x + 1;
+	g = (int)d;
+	return r;
+}`)
+	castsOnly := mustParse(t, `
+int g;
+long f(int x, double d) {
+	long r = (long)(x + 1);
+	g = d;
+	return (long)r;
+}`)
+	if changes := StructuralDiff(a, castsOnly); len(changes) != 0 {
+		t.Errorf("StructuralDiff with only casts changed = %+v, want none", changes)
+	}
+
+	logic := mustParse(t, `
+int g;
+long f(int x, double d) {
+	long r = (long)(x - 1);
+	g = (int)d;
+	return r;
+}`)
+	changes := StructuralDiff(a, logic)
+	if len(changes) != 1 {
+		t.Fatalf("StructuralDiff = %+v, want one change", changes)
+	}
+	c := changes[0]
+	if c.Kind != Modified || c.Func != "f" {
+		t.Errorf("change = %v in %q, want Modified in f", c.Kind, c.Func)
+	}
+	if got := c.New.(*Stmt).String(); got != "long r = (long)(x - 1);" {
+		t.Errorf("changed statement = %q, want the declaration of r", got)
+	}
+}
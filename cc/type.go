@@ -20,7 +20,9 @@ type Type struct {
 	Base     *Type
 	Tag      Syntax
 	Decls    []*Decl
+	Bases    []*Type
 	Width    *Expr
+	Static   bool // array parameter declared [static Width]
 	Name     Syntax
 	TypeDecl *Decl
 }
@@ -71,6 +73,7 @@ const (
 	Ulonglong
 	Float
 	Double
+	Longdouble
 	Enum
 	Ptr
 	Struct
@@ -94,6 +97,7 @@ var typeKindString = []string{
 	Ulonglong:   "ulonglong",
 	Float:       "float",
 	Double:      "double",
+	Longdouble:  "longdouble",
 	Ptr:         "pointer",
 	Struct:      "struct",
 	Union:       "union",
@@ -103,6 +107,24 @@ var typeKindString = []string{
 	TypedefType: "<typedef>",
 }
 
+// typeKindSpelling gives the C spelling of the basic type kinds.
+var typeKindSpelling = []string{
+	Void:       "void",
+	Char:       "char",
+	Uchar:      "unsigned char",
+	Short:      "short",
+	Ushort:     "unsigned short",
+	Int:        "int",
+	Uint:       "unsigned int",
+	Long:       "long",
+	Ulong:      "unsigned long",
+	Longlong:   "long long",
+	Ulonglong:  "unsigned long long",
+	Float:      "float",
+	Double:     "double",
+	Longdouble: "long double",
+}
+
 func (k TypeKind) String() string {
 	if 0 <= int(k) && int(k) <= len(typeKindString) && typeKindString[k] != "" {
 		return typeKindString[k]
@@ -115,6 +137,7 @@ type TypeQual int
 const (
 	Const TypeQual = 1 << iota
 	Volatile
+	Atomic
 )
 
 func (q TypeQual) String() string {
@@ -125,6 +148,9 @@ func (q TypeQual) String() string {
 	if q&Volatile != 0 {
 		s += "volatile "
 	}
+	if q&Atomic != 0 {
+		s += "_Atomic "
+	}
 	if s == "" {
 		return ""
 	}
@@ -140,6 +166,7 @@ const (
 	Typedef
 	Register
 	Inline
+	ThreadLocal
 )
 
 func (c Storage) String() string {
@@ -162,6 +189,9 @@ func (c Storage) String() string {
 	if c&Inline != 0 {
 		s += "inline "
 	}
+	if c&ThreadLocal != 0 {
+		s += "_Thread_local "
+	}
 	if s == "" {
 		return ""
 	}
@@ -169,20 +199,21 @@ func (c Storage) String() string {
 }
 
 var (
-	CharType      = newType(Char)
-	UcharType     = newType(Uchar)
-	ShortType     = newType(Short)
-	UshortType    = newType(Ushort)
-	IntType       = newType(Int)
-	UintType      = newType(Uint)
-	LongType      = newType(Long)
-	UlongType     = newType(Ulong)
-	LonglongType  = newType(Longlong)
-	UlonglongType = newType(Ulonglong)
-	FloatType     = newType(Float)
-	DoubleType    = newType(Double)
-	VoidType      = newType(Void)
-	BoolType      = &Type{Kind: TypedefType, Name: &SymbolLiteral{Value: "bool"}, Base: IntType}
+	CharType       = newType(Char)
+	UcharType      = newType(Uchar)
+	ShortType      = newType(Short)
+	UshortType     = newType(Ushort)
+	IntType        = newType(Int)
+	UintType       = newType(Uint)
+	LongType       = newType(Long)
+	UlongType      = newType(Ulong)
+	LonglongType   = newType(Longlong)
+	UlonglongType  = newType(Ulonglong)
+	FloatType      = newType(Float)
+	DoubleType     = newType(Double)
+	LongdoubleType = newType(Longdouble)
+	VoidType       = newType(Void)
+	BoolType       = &Type{Kind: TypedefType, Name: &SymbolLiteral{Value: "bool"}, Base: IntType}
 )
 
 type typeOp int
@@ -201,15 +232,17 @@ const (
 )
 
 var builtinTypes = map[typeOp]*Type{
-	tChar:                     CharType,
-	tChar | tSigned:           CharType,
-	tChar | tUnsigned:         UcharType,
-	tShort:                    ShortType,
-	tShort | tSigned:          ShortType,
-	tShort | tUnsigned:        UshortType,
-	tShort | tInt:             ShortType,
-	tShort | tSigned | tInt:   ShortType,
-	tShort | tUnsigned | tInt: UshortType,
+	tChar:                        CharType,
+	tChar | tSigned:              CharType,
+	tChar | tUnsigned:            UcharType,
+	tShort:                       ShortType,
+	tShort | tSigned:             ShortType,
+	tShort | tUnsigned:           UshortType,
+	tShort | tInt:                ShortType,
+	tShort | tSigned | tInt:      ShortType,
+	tShort | tUnsigned | tInt:    UshortType,
+	tSigned:                      IntType,
+	tUnsigned:                    UintType,
 	tInt:                         IntType,
 	tInt | tSigned:               IntType,
 	tInt | tUnsigned:             UintType,
@@ -225,9 +258,10 @@ var builtinTypes = map[typeOp]*Type{
 	tLonglong | tInt:             LonglongType,
 	tLonglong | tSigned | tInt:   LonglongType,
 	tLonglong | tUnsigned | tInt: UlonglongType,
-	tFloat:  FloatType,
-	tDouble: DoubleType,
-	tVoid:   VoidType,
+	tFloat:                       FloatType,
+	tDouble:                      DoubleType,
+	tLong | tDouble:              LongdoubleType,
+	tVoid:                        VoidType,
 }
 
 func splitTypeWords(ws []Syntax) (c Storage, q TypeQual, ty *Type) {
@@ -241,6 +275,8 @@ func splitTypeWords(ws []Syntax) (c Storage, q TypeQual, ty *Type) {
 			q |= Const
 		case "volatile":
 			q |= Volatile
+		case "_Atomic":
+			q |= Atomic
 		case "auto":
 			c |= Auto
 		case "static":
@@ -253,6 +289,8 @@ func splitTypeWords(ws []Syntax) (c Storage, q TypeQual, ty *Type) {
 			c |= Register
 		case "inline":
 			c |= Inline
+		case "_Thread_local":
+			c |= ThreadLocal
 		case "char":
 			t |= tChar
 			ts = append(ts, w)
@@ -303,6 +341,75 @@ func splitTypeWords(ws []Syntax) (c Storage, q TypeQual, ty *Type) {
 	return c, q, builtinTypes[t]
 }
 
+// qualify returns t with the qualifiers q added. Types such as IntType
+// are shared, so a type gaining qualifiers is copied.
+func qualify(t *Type, q TypeQual) *Type {
+	if t == nil || t.Qual&q == q {
+		return t
+	}
+	c := *t
+	c.Qual |= q
+	c.Id = nextId()
+	return &c
+}
+
+// quals returns the qualifiers of t, including those of the typedefs
+// it is named through.
+func quals(t *Type) TypeQual {
+	var q TypeQual
+	for ; t != nil; t = t.Base {
+		q |= t.Qual
+		if t.Kind != TypedefType {
+			break
+		}
+	}
+	return q
+}
+
+// Equal reports whether t and u denote the same type with the same
+// qualifiers, looking through typedef names. Tagged types are equal
+// when they have the same tag.
+func (t *Type) Equal(u *Type) bool {
+	if quals(t) != quals(u) {
+		return false
+	}
+	t, u = resolve(t), resolve(u)
+	if t == u {
+		return true
+	}
+	if t == nil || u == nil || t.Kind != u.Kind {
+		return false
+	}
+	switch t.Kind {
+	case Ptr:
+		return t.Base.Equal(u.Base)
+	case Array:
+		return t.Width.String() == u.Width.String() && t.Base.Equal(u.Base)
+	case Func:
+		if !t.Base.Equal(u.Base) || len(t.Decls) != len(u.Decls) {
+			return false
+		}
+		for i := range t.Decls {
+			if !t.Decls[i].Type.Equal(u.Decls[i].Type) {
+				return false
+			}
+		}
+	case Struct, Union, Enum:
+		if t.Tag.String() != "" || u.Tag.String() != "" {
+			return t.Tag.String() == u.Tag.String()
+		}
+		if len(t.Decls) != len(u.Decls) {
+			return false
+		}
+		for i := range t.Decls {
+			if t.Decls[i] != u.Decls[i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 func newType(k TypeKind) *Type {
 	return &Type{Kind: k}
 }
@@ -311,6 +418,14 @@ func (t *Type) String() string {
 	if t == nil {
 		return "<nil>"
 	}
+	if t.Qual != 0 {
+		u := *t
+		u.Qual = 0
+		if t.Kind == Ptr {
+			return u.String() + " " + t.Qual.String()
+		}
+		return t.Qual.String() + " " + u.String()
+	}
 	switch t.Kind {
 	default:
 		return t.Kind.String() + "<" + strconv.Itoa(t.Id) + ">"
@@ -334,7 +449,7 @@ func (t *Type) String() string {
 			if i > 0 {
 				s += ", "
 			}
-			s += d.Name.String() + " " + d.Type.String()
+			s += declName(d) + " " + d.Type.String()
 		}
 		if t.Base == t {
 			s += ") SELF"
@@ -385,8 +500,17 @@ func (d *Decl) String() string {
 		return "nil Decl"
 	}
 	if d.Init != nil {
-		return fmt.Sprintf("Decl<%d>{%s, %s} = %s", d.Id, d.Name.String(), d.Type, d.Init)
+		return fmt.Sprintf("Decl<%d>{%s, %s} = %s", d.Id, declName(d), d.Type, d.Init)
 	} else {
-		return fmt.Sprintf("Decl<%d>{%s, %s}", d.Id, d.Name.String(), d.Type)
+		return fmt.Sprintf("Decl<%d>{%s, %s}", d.Id, declName(d), d.Type)
+	}
+}
+
+// declName returns the name declared by d, or "" for an abstract
+// declaration such as the parameter in f(int).
+func declName(d *Decl) string {
+	if d.Name == nil {
+		return ""
 	}
+	return d.Name.String()
 }
@@ -0,0 +1,76 @@
+package cc
+
+// A TypeChange describes a named type whose definition differs between
+// two programs.
+type TypeChange struct {
+	Name string `json:"name"` // typedef name, or tagged type such as "struct S"
+	Old  string `json:"old"`  // definition in the old program
+	New  string `json:"new"`  // definition in the new program
+	Span Span   `json:"span"` // location of the new definition
+}
+
+// TypeChanges returns the typedefs and tagged types defined at file
+// scope in both a and b whose definitions differ, in the order of b.
+// Types defined in only one of the programs are not reported.
+func TypeChanges(a, b *Prog) []TypeChange {
+	old := map[string]string{}
+	for _, d := range typeDefs(a) {
+		old[d.name] = d.text
+	}
+	var changes []TypeChange
+	for _, d := range typeDefs(b) {
+		if text, ok := old[d.name]; ok && text != d.text {
+			changes = append(changes, TypeChange{Name: d.name, Old: text, New: d.text, Span: d.span})
+		}
+	}
+	return changes
+}
+
+type typeDef struct {
+	name, text string
+	span       Span
+}
+
+// typeDefs returns the typedefs and tagged type definitions of p, each
+// with its printed definition.
+func typeDefs(p *Prog) []typeDef {
+	var defs []typeDef
+	for _, d := range p.Decls {
+		if name := declName(d); d.Storage&Typedef != 0 && name != "" {
+			defs = append(defs, typeDef{name, fixtureDecl(d), d.Span})
+		}
+		if t := d.Type; t != nil && t.Tag != nil && t.Decls != nil {
+			defs = append(defs, typeDef{tagName(t), fixtureDecl(&Decl{Type: t}), d.Span})
+		}
+	}
+	return defs
+}
+
+// tagName returns the spelling of the tagged type t by its tag alone,
+// as in "struct S".
+func tagName(t *Type) string {
+	return (&Type{Kind: t.Kind, Tag: t.Tag}).Spelling()
+}
+
+// changedType returns the change to a type named in the spelling of t,
+// looking through pointers, arrays and typedefs, or nil if there is
+// none.
+func changedType(t *Type, changed map[string]*TypeChange, env *Env) *TypeChange {
+	for t != nil {
+		switch {
+		case t.Kind == TypedefType:
+			name := t.Name.String()
+			if c := changed[name]; c != nil {
+				return c
+			}
+			if u := env.Typedefs[name]; u != nil {
+				t = u
+				continue
+			}
+		case t.Tag != nil:
+			return changed[tagName(t)]
+		}
+		t = t.Base
+	}
+	return nil
+}
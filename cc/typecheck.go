@@ -18,13 +18,14 @@ func (lx *lexer) pushDecl(decl *Decl) {
 	if sc == nil {
 		panic("no scope")
 	}
-	if decl.Name.String() == "" {
+	name := declName(decl)
+	if name == "" {
 		return
 	}
 	if sc.Decl == nil {
 		sc.Decl = make(map[string]*Decl)
 	}
-	sc.Decl[decl.Name.String()] = decl
+	sc.Decl[name] = decl
 	if hdr := lx.declSave; hdr != nil && sc.Next == nil {
 		hdr.decls = append(hdr.decls, decl)
 	}
@@ -37,7 +38,14 @@ func (lx *lexer) lookupDecl(name Syntax) *Decl {
 			return decl
 		}
 	}
-	return nil
+	return universe[name.String()]
+}
+
+// universe holds the names predeclared by the language, outside every
+// scope. _Bool is modeled as a typedef of unsigned char; isBool tells it
+// apart.
+var universe = map[string]*Decl{
+	"_Bool": {Name: &SymbolLiteral{Value: "_Bool"}, Storage: Typedef, Type: UcharType},
 }
 
 func (lx *lexer) pushType(typ *Type) *Type {
@@ -48,6 +56,7 @@ func (lx *lexer) pushType(typ *Type) *Type {
 
 	if typ.Kind == Enum && typ.Decls != nil {
 		for _, decl := range typ.Decls {
+			decl.OuterType = typ
 			lx.pushDecl(decl)
 		}
 	}
@@ -83,6 +92,35 @@ func (lx *lexer) pushType(typ *Type) *Type {
 	return old
 }
 
+// pushClass pushes the C++ class or struct typ and, as C++ does,
+// makes its tag usable as a type name on its own.
+func (lx *lexer) pushClass(typ *Type) *Type {
+	typ = lx.pushType(typ)
+	name := typ.Tag.String()
+	if d := lx.lookupDecl(typ.Tag); d == nil || d.Storage&Typedef == 0 {
+		lx.pushDecl(&Decl{
+			SyntaxInfo: typ.SyntaxInfo,
+			Name:       &SymbolLiteral{SyntaxInfo: typ.SyntaxInfo, Value: name, Id: nextId()},
+			Type:       typ,
+			Storage:    Typedef,
+			Id:         nextId(),
+		})
+	}
+	return typ
+}
+
+// lookupBase returns the class named by tag in a base class list.
+func (lx *lexer) lookupBase(tag Syntax) *Type {
+	if typ := lx.lookupTag(tag.String()); typ != nil {
+		return typ
+	}
+	if d := lx.lookupDecl(tag); d != nil && d.Storage&Typedef != 0 {
+		return d.Type
+	}
+	lx.Errorf("unknown base class %s", tag)
+	return &Type{Kind: Struct, Tag: tag, Id: nextId()}
+}
+
 func (lx *lexer) lookupTag(name string) *Type {
 	for sc := lx.scope; sc != nil; sc = sc.Next {
 		typ := sc.Tag[name]
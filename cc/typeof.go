@@ -0,0 +1,294 @@
+package cc
+
+import (
+	"strconv"
+	"strings"
+)
+
+// TypeOf returns the type of the expression x, derived from the
+// declarations and literals it refers to, or nil if the type cannot be
+// determined (for example, a call of an undeclared function).
+// The result is cached in x.XType.
+func (x *Expr) TypeOf() *Type {
+	if x == nil {
+		return nil
+	}
+	if x.XType == nil {
+		x.XType = x.typeOf()
+	}
+	return x.XType
+}
+
+func (x *Expr) typeOf() *Type {
+	switch x.Op {
+	case Name:
+		if x.XDecl == nil {
+			return nil
+		}
+		if x.XDecl.Type != nil {
+			return x.XDecl.Type
+		}
+		return x.XDecl.OuterType
+
+	case Literal:
+		switch lit := x.Text.(type) {
+		case *IntegerLiteral:
+			return lit.typ()
+		case *CharLiteral:
+			return IntType
+		case *RealLiteral:
+			return DoubleType
+		}
+
+	case String:
+		return &Type{Kind: Ptr, Base: CharType}
+
+	case Cast, CastInit, CxxCast, VaArg:
+		return x.Type
+
+	case Paren, PreInc, PreDec, PostInc, PostDec,
+		Eq, AddEq, SubEq, MulEq, DivEq, ModEq, LshEq, RshEq, AndEq, OrEq, XorEq:
+		return x.Left.TypeOf()
+
+	case Plus, Minus, Twid, Lsh, Rsh:
+		return promote(x.Left.TypeOf())
+
+	case Not, AndAnd, OrOr, EqEq, NotEq, Lt, LtEq, Gt, GtEq:
+		return IntType
+
+	case SizeofExpr, SizeofType, Offsetof:
+		return UlongType
+
+	case Addr:
+		if t := x.Left.TypeOf(); t != nil {
+			return &Type{Kind: Ptr, Base: t}
+		}
+
+	case Indir:
+		return elemType(x.Left.TypeOf())
+
+	case Index:
+		if t := elemType(x.Left.TypeOf()); t != nil {
+			return t
+		}
+		return elemType(x.Right.TypeOf())
+
+	case Call, CUDACall:
+		t := resolve(x.Left.TypeOf())
+		if t != nil && t.Kind == Ptr {
+			t = resolve(t.Base)
+		}
+		if t != nil && t.Kind == Func {
+			return t.Base
+		}
+
+	case Dot:
+		return fieldType(x.Left.TypeOf(), x.Text.String())
+
+	case Arrow:
+		return fieldType(elemType(x.Left.TypeOf()), x.Text.String())
+
+	case Cond:
+		l, r := x.List[1].TypeOf(), x.List[2].TypeOf()
+		if isArith(l) || isArith(r) {
+			return arithType(l, r)
+		}
+		return l
+
+	case Comma:
+		return x.List[len(x.List)-1].TypeOf()
+
+	case Add, Sub:
+		l, r := x.Left.TypeOf(), x.Right.TypeOf()
+		if isPointer(l) && isPointer(r) {
+			return LongType
+		}
+		if isPointer(l) {
+			return l
+		}
+		if isPointer(r) {
+			return r
+		}
+		return arithType(l, r)
+
+	case Mul, Div, Mod, And, Or, Xor:
+		return arithType(x.Left.TypeOf(), x.Right.TypeOf())
+
+	case Builtin:
+		switch x.Text.String() {
+		case "__builtin_types_compatible_p":
+			return IntType
+		case "__builtin_offsetof":
+			return UlongType
+		}
+	}
+	return nil
+}
+
+// isArith reports whether t is an integer or floating type.
+func isArith(t *Type) bool {
+	return t.IsInteger() || t.FloatRank() > 0
+}
+
+// typ returns the type of the integer constant x, the first of the
+// types its suffix and radix allow, as listed in C11 6.4.4.1, that can
+// represent its value in the default data model. A decimal constant
+// without a u suffix is never unsigned.
+func (x *IntegerLiteral) typ() *Type {
+	text := strings.ToLower(x.Text)
+	if text == "" {
+		return IntType
+	}
+	digits := strings.TrimRight(text, "ul")
+	suffix := text[len(digits):]
+	v, err := strconv.ParseUint(digits, 0, 64)
+	if err != nil {
+		return nil
+	}
+	decimal := digits == "0" || digits[0] != '0'
+	unsigned := strings.Contains(suffix, "u")
+	var types []*Type
+	switch strings.Count(suffix, "l") {
+	case 0:
+		types = []*Type{IntType, UintType, LongType, UlongType, LonglongType, UlonglongType}
+	case 1:
+		types = []*Type{LongType, UlongType, LonglongType, UlonglongType}
+	default:
+		types = []*Type{LonglongType, UlonglongType}
+	}
+	for _, t := range types {
+		if t.IsUnsigned() && !unsigned && decimal || !t.IsUnsigned() && unsigned {
+			continue
+		}
+		bits := uint(DefaultModel.Bits(t))
+		if !t.IsUnsigned() {
+			bits--
+		}
+		if bits >= 64 || v < 1<<bits {
+			return t
+		}
+	}
+	return nil
+}
+
+// resolve returns t with any typedefs stripped.
+func resolve(t *Type) *Type {
+	for t != nil && t.Kind == TypedefType && t.Base != nil {
+		t = t.Base
+	}
+	return t
+}
+
+func isPointer(t *Type) bool {
+	t = resolve(t)
+	return t != nil && (t.Kind == Ptr || t.Kind == Array)
+}
+
+// elemType returns the type pointed to by, or the element type of, t.
+func elemType(t *Type) *Type {
+	t = resolve(t)
+	if t == nil || t.Kind != Ptr && t.Kind != Array {
+		return nil
+	}
+	return t.Base
+}
+
+// fieldType returns the type of the named field of struct or union t.
+func fieldType(t *Type, name string) *Type {
+	t = resolve(t)
+	if t == nil {
+		return nil
+	}
+	for _, d := range t.Decls {
+		if d.Name != nil && d.Name.String() == name {
+			return d.Type
+		}
+	}
+	return nil
+}
+
+// intRank orders the integer kinds by conversion rank.
+var intRank = map[TypeKind]int{
+	Char:      1,
+	Uchar:     1,
+	Short:     2,
+	Ushort:    2,
+	Int:       3,
+	Uint:      3,
+	Enum:      3,
+	Long:      4,
+	Ulong:     4,
+	Longlong:  5,
+	Ulonglong: 5,
+}
+
+// IsInteger reports whether t is an integer (or enum) type.
+func (t *Type) IsInteger() bool {
+	t = resolve(t)
+	return t != nil && intRank[t.Kind] != 0
+}
+
+// IsUnsigned reports whether t is an unsigned integer type.
+func (t *Type) IsUnsigned() bool {
+	t = resolve(t)
+	if t == nil {
+		return false
+	}
+	switch t.Kind {
+	case Uchar, Ushort, Uint, Ulong, Ulonglong:
+		return true
+	}
+	return false
+}
+
+// FloatRank returns the rank of the floating type t: 1 for float,
+// 2 for double and 3 for long double. It returns 0 if t is not a
+// floating type.
+func (t *Type) FloatRank() int {
+	t = resolve(t)
+	if t == nil {
+		return 0
+	}
+	switch t.Kind {
+	case Float:
+		return 1
+	case Double:
+		return 2
+	case Longdouble:
+		return 3
+	}
+	return 0
+}
+
+// promote applies the integer promotions to t.
+func promote(t *Type) *Type {
+	if rt := resolve(t); rt != nil && intRank[rt.Kind] != 0 && intRank[rt.Kind] < intRank[Int] {
+		return IntType
+	}
+	return t
+}
+
+// arithType returns the type of a binary arithmetic operation on
+// operands of type l and r, following the usual arithmetic conversions.
+func arithType(l, r *Type) *Type {
+	if l == nil || r == nil {
+		return nil
+	}
+	if l.FloatRank() > 0 || r.FloatRank() > 0 {
+		if l.FloatRank() >= r.FloatRank() {
+			return l
+		}
+		return r
+	}
+	l, r = promote(l), promote(r)
+	lr, rr := intRank[resolve(l).Kind], intRank[resolve(r).Kind]
+	switch {
+	case lr > rr:
+		return l
+	case rr > lr:
+		return r
+	case r.IsUnsigned():
+		return r
+	}
+	return l
+}
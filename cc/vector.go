@@ -0,0 +1,38 @@
+package cc
+
+// vectorElems maps the prefix of each CUDA vector type name, such as
+// float in float4, to its element type.
+var vectorElems = map[string]*Type{
+	"char":      CharType,
+	"uchar":     UcharType,
+	"short":     ShortType,
+	"ushort":    UshortType,
+	"int":       IntType,
+	"uint":      UintType,
+	"long":      LongType,
+	"ulong":     UlongType,
+	"longlong":  LonglongType,
+	"ulonglong": UlonglongType,
+	"float":     FloatType,
+	"double":    DoubleType,
+}
+
+// Vector reports whether t is one of the CUDA vector types declared in
+// vector_types.h, such as float4, and if so returns its element type and
+// number of elements. It returns nil, 0 for any other type.
+func (t *Type) Vector() (elem *Type, n int) {
+	for ; t != nil && t.Kind == TypedefType; t = t.Base {
+		name := t.Name.String()
+		if len(name) < 2 {
+			continue
+		}
+		last := name[len(name)-1]
+		if last < '1' || last > '4' {
+			continue
+		}
+		if elem := vectorElems[name[:len(name)-1]]; elem != nil {
+			return elem, int(last - '0')
+		}
+	}
+	return nil, 0
+}
@@ -1,9 +1,12 @@
+// Code generated by goyacc -o y.go cc.y. DO NOT EDIT.
+
 //line cc.y:34
 package cc
 
 import __yyfmt__ "fmt"
 
 //line cc.y:34
+
 import (
 // "runtime/debug"
 )
@@ -52,6 +55,7 @@ type yySymType struct {
 	tc       typeClass
 	tk       TypeKind
 	typ      *Type
+	typs     []*Type
 	symlit   *SymbolLiteral
 	boollit  *BooleanLiteral
 	reallit  *RealLiteral
@@ -116,37 +120,45 @@ const tokHost = 57395
 const tokGlobal = 57396
 const tokShared = 57397
 const tokRestrict = 57398
-const tokShift = 57399
-const tokElse = 57400
-const tokAddEq = 57401
-const tokSubEq = 57402
-const tokMulEq = 57403
-const tokDivEq = 57404
-const tokModEq = 57405
-const tokLshEq = 57406
-const tokRshEq = 57407
-const tokAndEq = 57408
-const tokXorEq = 57409
-const tokOrEq = 57410
-const tokOrOr = 57411
-const tokAndAnd = 57412
-const tokEqEq = 57413
-const tokNotEq = 57414
-const tokLtEq = 57415
-const tokGtEq = 57416
-const tokLsh = 57417
-const tokRsh = 57418
-const tokCast = 57419
-const tokSizeof = 57420
-const tokUnary = 57421
-const tokDec = 57422
-const tokInc = 57423
-const tokArrow = 57424
-const startProg = 57425
-const startExpr = 57426
-const tokEOF = 57427
-
-var yyToknames = []string{
+const tokBuiltin = 57399
+const tokClass = 57400
+const tokAtomic = 57401
+const tokThreadLocal = 57402
+const tokCxxCast = 57403
+const tokShift = 57404
+const tokElse = 57405
+const tokAddEq = 57406
+const tokSubEq = 57407
+const tokMulEq = 57408
+const tokDivEq = 57409
+const tokModEq = 57410
+const tokLshEq = 57411
+const tokRshEq = 57412
+const tokAndEq = 57413
+const tokXorEq = 57414
+const tokOrEq = 57415
+const tokOrOr = 57416
+const tokAndAnd = 57417
+const tokEqEq = 57418
+const tokNotEq = 57419
+const tokLtEq = 57420
+const tokGtEq = 57421
+const tokLsh = 57422
+const tokRsh = 57423
+const tokCast = 57424
+const tokSizeof = 57425
+const tokUnary = 57426
+const tokDec = 57427
+const tokInc = 57428
+const tokArrow = 57429
+const startProg = 57430
+const startExpr = 57431
+const tokEOF = 57432
+
+var yyToknames = [...]string{
+	"$end",
+	"error",
+	"$unk",
 	"tokARGBEGIN",
 	"tokARGEND",
 	"tokAUTOLIB",
@@ -200,6 +212,11 @@ var yyToknames = []string{
 	"tokGlobal",
 	"tokShared",
 	"tokRestrict",
+	"tokBuiltin",
+	"tokClass",
+	"tokAtomic",
+	"tokThreadLocal",
+	"tokCxxCast",
 	"tokShift",
 	"tokElse",
 	"'{'",
@@ -251,454 +268,574 @@ var yyToknames = []string{
 	"startProg",
 	"startExpr",
 	"tokEOF",
+	"'}'",
+	"';'",
 }
-var yyStatenames = []string{}
+
+var yyStatenames = [...]string{}
 
 const yyEofCode = 1
 const yyErrCode = 2
-const yyMaxDepth = 200
+const yyInitialStackSize = 16
 
 //line yacctab:1
-var yyExca = []int{
+var yyExca = [...]int16{
 	-1, 1,
 	1, -1,
 	-2, 0,
-	-1, 125,
-	60, 102,
-	109, 102,
-	-2, 187,
-	-1, 143,
-	59, 178,
-	-2, 152,
-	-1, 145,
-	59, 178,
-	-2, 157,
-	-1, 247,
-	109, 213,
-	-2, 177,
-	-1, 279,
-	73, 178,
-	-2, 93,
+	-1, 132,
+	65, 108,
+	114, 108,
+	-2, 215,
+	-1, 152,
+	64, 204,
+	-2, 162,
+	-1, 154,
+	64, 167,
+	-2, 165,
+	-1, 155,
+	64, 204,
+	-2, 176,
+	-1, 231,
+	64, 181,
+	-2, 179,
+	-1, 280,
+	114, 241,
+	-2, 203,
+	-1, 328,
+	78, 204,
+	-2, 98,
 }
 
-const yyNprod = 223
 const yyPrivate = 57344
 
-var yyTokenNames []string
-var yyStates []string
-
-const yyLast = 1658
-
-var yyAct = []int{
-
-	321, 7, 119, 127, 354, 314, 269, 32, 234, 222,
-	276, 290, 204, 118, 249, 105, 106, 107, 108, 109,
-	110, 111, 112, 113, 228, 201, 6, 355, 246, 124,
-	50, 5, 178, 226, 116, 130, 232, 320, 236, 4,
-	140, 138, 143, 145, 136, 125, 389, 387, 380, 379,
-	375, 117, 33, 368, 366, 349, 348, 346, 300, 299,
-	308, 195, 317, 303, 254, 65, 36, 374, 147, 148,
-	149, 150, 151, 152, 153, 154, 155, 156, 157, 158,
-	159, 160, 161, 162, 163, 164, 165, 137, 167, 168,
-	169, 170, 171, 172, 173, 174, 175, 176, 177, 197,
-	135, 131, 141, 131, 35, 196, 182, 183, 307, 2,
-	3, 189, 132, 166, 132, 357, 11, 97, 8, 9,
-	10, 22, 356, 192, 179, 179, 191, 181, 188, 198,
-	197, 180, 244, 24, 353, 351, 196, 25, 134, 345,
-	142, 344, 117, 252, 221, 122, 121, 218, 115, 184,
-	185, 392, 72, 73, 67, 68, 69, 70, 71, 203,
-	128, 219, 266, 259, 103, 99, 197, 219, 101, 100,
-	102, 98, 196, 360, 386, 129, 206, 267, 205, 378,
-	207, 17, 18, 21, 377, 376, 137, 373, 216, 20,
-	19, 372, 23, 306, 97, 311, 295, 294, 262, 233,
-	235, 141, 239, 224, 135, 214, 141, 212, 187, 186,
-	230, 293, 251, 241, 242, 220, 216, 253, 268, 213,
-	203, 233, 247, 359, 302, 217, 210, 284, 32, 301,
-	257, 291, 292, 243, 225, 230, 240, 238, 281, 142,
-	264, 103, 99, 265, 142, 101, 100, 102, 98, 263,
-	215, 279, 241, 217, 66, 258, 256, 235, 260, 247,
-	277, 200, 270, 209, 288, 208, 194, 388, 211, 271,
-	123, 104, 364, 131, 273, 34, 278, 230, 250, 179,
-	97, 193, 309, 280, 132, 285, 305, 237, 296, 298,
-	1, 38, 297, 12, 313, 312, 304, 203, 202, 139,
-	49, 310, 324, 289, 316, 279, 323, 257, 325, 133,
-	242, 235, 315, 255, 277, 144, 146, 239, 318, 69,
-	70, 71, 287, 58, 126, 282, 283, 103, 99, 274,
-	329, 101, 100, 102, 98, 350, 275, 347, 248, 245,
-	352, 29, 27, 358, 227, 199, 30, 190, 0, 0,
-	239, 330, 0, 0, 0, 0, 365, 59, 0, 0,
-	0, 0, 60, 61, 62, 63, 64, 0, 0, 0,
-	0, 0, 361, 362, 0, 0, 0, 383, 384, 385,
-	382, 367, 0, 0, 369, 370, 0, 53, 0, 391,
-	40, 58, 390, 393, 0, 0, 47, 39, 97, 120,
-	46, 0, 381, 0, 57, 42, 11, 43, 8, 9,
-	10, 22, 56, 0, 41, 44, 54, 51, 0, 37,
-	55, 52, 45, 24, 48, 59, 0, 25, 0, 0,
-	60, 61, 62, 63, 64, 67, 68, 69, 70, 71,
-	0, 0, 0, 0, 0, 103, 99, 0, 0, 101,
-	100, 102, 98, 0, 0, 0, 14, 0, 0, 0,
-	0, 0, 0, 0, 0, 15, 16, 13, 0, 0,
-	0, 17, 18, 21, 97, 0, 0, 0, 0, 20,
-	19, 0, 23, 0, 0, 86, 87, 88, 89, 90,
-	91, 92, 93, 94, 95, 96, 85, 371, 84, 83,
-	82, 81, 80, 78, 79, 74, 75, 76, 77, 72,
-	73, 67, 68, 69, 70, 71, 0, 0, 0, 0,
-	0, 103, 99, 0, 0, 101, 100, 102, 98, 331,
-	0, 0, 328, 327, 0, 332, 341, 0, 0, 333,
-	342, 334, 0, 0, 0, 0, 0, 0, 335, 336,
-	337, 0, 0, 11, 97, 343, 9, 10, 22, 0,
-	338, 0, 0, 0, 0, 339, 0, 0, 0, 0,
-	24, 0, 0, 340, 25, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 270, 74, 75, 76, 77, 72,
-	73, 67, 68, 69, 70, 71, 0, 0, 0, 0,
-	0, 103, 99, 14, 0, 101, 100, 102, 98, 0,
-	0, 0, 15, 16, 13, 0, 0, 0, 17, 18,
-	21, 0, 0, 0, 97, 0, 20, 19, 0, 23,
-	0, 0, 0, 0, 326, 86, 87, 88, 89, 90,
-	91, 92, 93, 94, 95, 96, 85, 0, 84, 83,
-	82, 81, 80, 78, 79, 74, 75, 76, 77, 72,
-	73, 67, 68, 69, 70, 71, 0, 0, 97, 0,
-	0, 103, 99, 319, 0, 101, 100, 102, 98, 86,
-	87, 88, 89, 90, 91, 92, 93, 94, 95, 96,
-	85, 0, 84, 83, 82, 81, 80, 78, 79, 74,
-	75, 76, 77, 72, 73, 67, 68, 69, 70, 71,
-	0, 0, 0, 97, 0, 103, 99, 0, 286, 101,
-	100, 102, 98, 223, 86, 87, 88, 89, 90, 91,
-	92, 93, 94, 95, 96, 85, 0, 84, 83, 82,
-	81, 80, 78, 79, 74, 75, 76, 77, 72, 73,
-	67, 68, 69, 70, 71, 0, 0, 97, 0, 0,
-	103, 99, 0, 0, 101, 100, 102, 98, 86, 87,
-	88, 89, 90, 91, 92, 93, 94, 95, 96, 85,
-	0, 84, 83, 82, 81, 80, 78, 79, 74, 75,
-	76, 77, 72, 73, 67, 68, 69, 70, 71, 0,
-	28, 0, 0, 53, 103, 99, 40, 58, 101, 100,
-	102, 98, 47, 39, 0, 31, 46, 0, 0, 0,
-	57, 42, 0, 43, 0, 0, 0, 0, 56, 0,
-	41, 44, 54, 51, 0, 37, 55, 52, 45, 0,
-	48, 59, 0, 0, 0, 0, 60, 61, 62, 63,
-	64, 53, 0, 0, 40, 58, 0, 0, 0, 0,
-	47, 39, 0, 120, 46, 0, 0, 0, 57, 42,
-	0, 43, 0, 0, 0, 0, 56, 0, 41, 44,
-	54, 51, 0, 37, 55, 52, 45, 0, 48, 59,
-	0, 0, 0, 0, 60, 61, 62, 63, 64, 0,
-	53, 0, 261, 40, 58, 0, 0, 0, 0, 47,
-	39, 0, 120, 46, 0, 0, 0, 57, 42, 0,
-	43, 0, 0, 0, 0, 56, 0, 41, 44, 54,
-	51, 0, 37, 55, 52, 45, 0, 48, 59, 0,
-	0, 0, 0, 60, 61, 62, 63, 64, 28, 0,
-	322, 53, 0, 0, 40, 58, 0, 0, 0, 0,
-	47, 39, 0, 31, 46, 0, 0, 0, 57, 42,
-	0, 43, 0, 0, 0, 0, 56, 0, 41, 44,
-	54, 51, 97, 37, 55, 52, 45, 0, 48, 59,
-	0, 0, 0, 0, 60, 61, 62, 63, 64, 272,
-	0, 0, 0, 0, 85, 0, 84, 83, 82, 81,
-	80, 78, 79, 74, 75, 76, 77, 72, 73, 67,
-	68, 69, 70, 71, 0, 97, 0, 0, 0, 103,
-	99, 0, 0, 101, 100, 102, 98, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 26,
-	83, 82, 81, 80, 78, 79, 74, 75, 76, 77,
-	72, 73, 67, 68, 69, 70, 71, 97, 0, 0,
-	0, 0, 103, 99, 0, 0, 101, 100, 102, 98,
+const yyLast = 2257
+
+var yyAct = [...]int16{
+	387, 7, 377, 124, 421, 134, 314, 261, 34, 331,
+	219, 325, 278, 342, 244, 110, 111, 112, 113, 114,
+	115, 116, 117, 118, 287, 274, 254, 422, 54, 286,
+	123, 5, 277, 189, 216, 148, 252, 131, 6, 121,
+	228, 258, 263, 147, 137, 132, 146, 152, 154, 155,
+	273, 122, 4, 386, 143, 35, 456, 454, 447, 446,
+	442, 435, 433, 102, 416, 415, 413, 358, 38, 355,
+	210, 390, 383, 158, 159, 160, 161, 162, 163, 164,
+	165, 166, 167, 168, 169, 170, 171, 172, 173, 174,
+	175, 176, 144, 178, 179, 180, 181, 182, 183, 184,
+	185, 186, 187, 188, 142, 381, 149, 74, 75, 76,
+	369, 363, 294, 193, 194, 108, 104, 37, 177, 106,
+	105, 107, 103, 102, 70, 138, 2, 3, 203, 138,
+	200, 441, 192, 212, 213, 271, 139, 191, 199, 211,
+	139, 190, 190, 212, 424, 202, 423, 122, 420, 211,
+	418, 412, 411, 141, 71, 150, 304, 292, 368, 195,
+	196, 204, 243, 231, 218, 151, 190, 206, 207, 129,
+	127, 212, 126, 120, 459, 108, 104, 211, 345, 106,
+	105, 107, 103, 222, 241, 248, 300, 453, 221, 135,
+	445, 223, 444, 310, 349, 220, 144, 443, 440, 238,
+	372, 439, 367, 348, 136, 347, 306, 272, 311, 250,
+	149, 246, 236, 142, 260, 262, 149, 234, 266, 198,
+	197, 312, 235, 249, 241, 247, 268, 269, 128, 218,
+	275, 427, 426, 275, 291, 242, 343, 344, 238, 293,
+	239, 360, 256, 260, 322, 229, 270, 232, 227, 224,
+	335, 317, 318, 332, 359, 34, 280, 298, 318, 150,
+	267, 313, 251, 307, 237, 150, 71, 309, 265, 215,
+	336, 308, 290, 285, 256, 315, 63, 268, 282, 239,
+	299, 283, 319, 296, 281, 301, 328, 302, 303, 230,
+	321, 326, 225, 209, 455, 233, 130, 262, 149, 346,
+	138, 109, 431, 340, 316, 288, 350, 36, 280, 327,
+	64, 139, 280, 330, 329, 65, 66, 67, 68, 69,
+	208, 222, 145, 370, 275, 138, 337, 264, 157, 354,
+	276, 190, 1, 226, 351, 205, 139, 365, 256, 218,
+	362, 139, 321, 140, 357, 352, 374, 150, 280, 373,
+	356, 153, 289, 156, 149, 371, 41, 364, 12, 217,
+	280, 53, 380, 269, 391, 328, 366, 298, 341, 321,
+	326, 379, 262, 389, 392, 295, 382, 339, 384, 133,
+	333, 266, 57, 334, 323, 324, 63, 280, 31, 29,
+	253, 214, 32, 201, 125, 0, 396, 0, 0, 61,
+	0, 414, 417, 150, 0, 0, 0, 60, 0, 419,
+	425, 58, 0, 0, 0, 59, 0, 397, 266, 0,
+	64, 0, 0, 432, 0, 65, 66, 67, 68, 69,
+	0, 0, 145, 62, 0, 0, 0, 0, 0, 428,
+	429, 0, 0, 0, 450, 451, 452, 449, 434, 0,
+	0, 436, 437, 0, 57, 0, 458, 44, 63, 457,
+	460, 0, 0, 51, 43, 0, 125, 50, 102, 448,
+	0, 61, 46, 11, 47, 8, 9, 10, 22, 60,
+	0, 45, 48, 58, 55, 0, 39, 59, 56, 49,
+	24, 52, 64, 0, 27, 0, 0, 65, 66, 67,
+	68, 69, 25, 42, 40, 62, 26, 0, 77, 78,
+	72, 73, 74, 75, 76, 0, 0, 0, 0, 0,
+	108, 104, 0, 0, 106, 105, 107, 103, 14, 0,
+	0, 0, 0, 0, 0, 0, 0, 15, 16, 13,
+	0, 0, 0, 17, 18, 21, 0, 0, 0, 0,
+	0, 20, 19, 398, 23, 0, 395, 394, 0, 399,
+	408, 0, 0, 400, 409, 401, 0, 0, 0, 0,
+	0, 0, 402, 403, 404, 0, 0, 11, 102, 410,
+	9, 10, 22, 0, 405, 0, 0, 0, 0, 406,
+	0, 0, 0, 0, 24, 0, 0, 407, 27, 0,
+	0, 0, 0, 0, 0, 0, 25, 0, 0, 0,
+	26, 0, 0, 315, 79, 80, 81, 82, 77, 78,
+	72, 73, 74, 75, 76, 0, 0, 0, 0, 0,
+	108, 104, 14, 0, 106, 105, 107, 103, 0, 0,
+	0, 15, 16, 13, 0, 0, 0, 17, 18, 21,
+	102, 0, 0, 0, 0, 20, 19, 0, 23, 0,
+	0, 0, 0, 393, 0, 0, 91, 92, 93, 94,
+	95, 96, 97, 98, 99, 100, 101, 90, 438, 89,
+	88, 87, 86, 85, 83, 84, 79, 80, 81, 82,
+	77, 78, 72, 73, 74, 75, 76, 102, 0, 0,
+	0, 0, 108, 104, 0, 0, 106, 105, 107, 103,
+	0, 0, 0, 91, 92, 93, 94, 95, 96, 97,
+	98, 99, 100, 101, 90, 0, 89, 88, 87, 86,
+	85, 83, 84, 79, 80, 81, 82, 77, 78, 72,
+	73, 74, 75, 76, 102, 0, 0, 0, 0, 108,
+	104, 385, 0, 106, 105, 107, 103, 0, 0, 0,
+	91, 92, 93, 94, 95, 96, 97, 98, 99, 100,
+	101, 90, 0, 89, 88, 87, 86, 85, 83, 84,
+	79, 80, 81, 82, 77, 78, 72, 73, 74, 75,
+	76, 102, 0, 0, 0, 0, 108, 104, 0, 376,
+	106, 105, 107, 103, 0, 0, 0, 91, 92, 93,
+	94, 95, 96, 97, 98, 99, 100, 101, 90, 0,
+	89, 88, 87, 86, 85, 83, 84, 79, 80, 81,
+	82, 77, 78, 72, 73, 74, 75, 76, 102, 0,
+	0, 0, 0, 108, 104, 375, 0, 106, 105, 107,
+	103, 0, 0, 0, 91, 92, 93, 94, 95, 96,
+	97, 98, 99, 100, 101, 90, 0, 89, 88, 87,
+	86, 85, 83, 84, 79, 80, 81, 82, 77, 78,
+	72, 73, 74, 75, 76, 102, 0, 0, 0, 0,
+	108, 104, 353, 0, 106, 105, 107, 103, 0, 0,
+	0, 91, 92, 93, 94, 95, 96, 97, 98, 99,
+	100, 101, 90, 0, 89, 88, 87, 86, 85, 83,
+	84, 79, 80, 81, 82, 77, 78, 72, 73, 74,
+	75, 76, 102, 0, 0, 0, 0, 108, 104, 0,
+	338, 106, 105, 107, 103, 0, 0, 245, 91, 92,
+	93, 94, 95, 96, 97, 98, 99, 100, 101, 90,
+	0, 89, 88, 87, 86, 85, 83, 84, 79, 80,
+	81, 82, 77, 78, 72, 73, 74, 75, 76, 102,
+	0, 0, 0, 0, 108, 104, 0, 0, 106, 105,
+	107, 103, 0, 0, 0, 91, 92, 93, 94, 95,
+	96, 97, 98, 99, 100, 101, 90, 0, 89, 88,
+	87, 86, 85, 83, 84, 79, 80, 81, 82, 77,
+	78, 72, 73, 74, 75, 76, 0, 0, 0, 0,
+	57, 108, 104, 44, 63, 106, 105, 107, 103, 51,
+	43, 0, 125, 50, 0, 0, 0, 61, 46, 0,
+	47, 279, 0, 0, 0, 60, 0, 45, 48, 58,
+	55, 0, 39, 59, 56, 49, 0, 52, 64, 0,
+	0, 0, 0, 65, 66, 67, 68, 69, 0, 42,
+	40, 62, 57, 0, 0, 44, 63, 0, 0, 0,
+	0, 51, 43, 0, 125, 50, 0, 0, 0, 61,
+	46, 0, 47, 279, 0, 0, 0, 60, 0, 45,
+	48, 58, 55, 0, 39, 59, 56, 49, 0, 52,
+	64, 0, 0, 0, 0, 65, 66, 67, 68, 69,
+	0, 42, 40, 62, 378, 0, 0, 0, 57, 0,
+	0, 44, 63, 0, 0, 0, 0, 51, 43, 0,
+	125, 50, 0, 0, 0, 61, 46, 0, 47, 279,
+	0, 0, 0, 60, 0, 45, 48, 58, 55, 0,
+	39, 59, 56, 49, 0, 52, 64, 0, 0, 0,
+	0, 65, 66, 67, 68, 69, 361, 42, 40, 62,
+	0, 30, 0, 0, 57, 0, 0, 44, 63, 0,
+	0, 0, 0, 51, 43, 0, 33, 50, 0, 0,
+	0, 61, 46, 0, 47, 0, 0, 0, 0, 60,
+	0, 45, 48, 58, 55, 0, 39, 59, 56, 49,
+	0, 52, 64, 0, 0, 0, 0, 65, 66, 67,
+	68, 69, 320, 42, 40, 62, 57, 0, 0, 44,
+	63, 0, 0, 0, 0, 51, 43, 0, 125, 50,
+	0, 0, 0, 61, 46, 0, 47, 0, 0, 0,
+	0, 60, 0, 45, 48, 58, 55, 0, 39, 59,
+	56, 49, 0, 52, 64, 0, 0, 0, 0, 65,
+	66, 67, 68, 69, 0, 42, 40, 62, 305, 30,
+	0, 0, 57, 0, 0, 44, 63, 0, 0, 0,
+	0, 51, 43, 0, 33, 50, 0, 0, 0, 61,
+	46, 0, 47, 0, 0, 0, 0, 60, 102, 45,
+	48, 58, 55, 0, 39, 59, 56, 49, 0, 52,
+	64, 0, 0, 0, 0, 65, 66, 67, 68, 69,
+	388, 42, 40, 62, 0, 90, 0, 89, 88, 87,
+	86, 85, 83, 84, 79, 80, 81, 82, 77, 78,
+	72, 73, 74, 75, 76, 0, 102, 0, 0, 0,
+	108, 104, 0, 0, 106, 105, 107, 103, 0, 0,
+	0, 0, 0, 0, 102, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 28, 88, 87, 86, 85,
+	83, 84, 79, 80, 81, 82, 77, 78, 72, 73,
+	74, 75, 76, 102, 0, 0, 0, 0, 108, 104,
+	0, 0, 106, 105, 107, 103, 72, 73, 74, 75,
+	76, 0, 0, 0, 0, 0, 108, 104, 0, 0,
+	106, 105, 107, 103, 87, 86, 85, 83, 84, 79,
+	80, 81, 82, 77, 78, 72, 73, 74, 75, 76,
+	0, 0, 0, 0, 0, 108, 104, 0, 0, 106,
+	105, 107, 103, 11, 0, 8, 9, 10, 22, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 82, 81, 80, 78, 79, 74, 75,
-	76, 77, 72, 73, 67, 68, 69, 70, 71, 97,
-	0, 0, 0, 0, 103, 99, 0, 0, 101, 100,
-	102, 98, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 81, 80, 78, 79,
-	74, 75, 76, 77, 72, 73, 67, 68, 69, 70,
-	71, 97, 0, 0, 0, 0, 103, 99, 0, 0,
-	101, 100, 102, 98, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 80,
-	78, 79, 74, 75, 76, 77, 72, 73, 67, 68,
-	69, 70, 71, 0, 0, 0, 0, 0, 103, 99,
-	0, 0, 101, 100, 102, 98, 11, 0, 8, 9,
-	10, 22, 0, 0, 53, 0, 0, 0, 58, 0,
-	0, 0, 0, 24, 0, 0, 120, 25, 0, 0,
-	0, 57, 0, 0, 0, 0, 0, 218, 0, 56,
-	0, 0, 0, 54, 0, 0, 0, 55, 0, 0,
-	0, 0, 59, 0, 97, 0, 14, 60, 61, 62,
-	63, 64, 0, 0, 0, 15, 16, 13, 0, 0,
-	0, 17, 18, 21, 0, 291, 292, 0, 0, 20,
-	19, 97, 23, 78, 79, 74, 75, 76, 77, 72,
-	73, 67, 68, 69, 70, 71, 0, 0, 0, 0,
-	0, 103, 99, 0, 0, 101, 100, 102, 98, 0,
-	0, 79, 74, 75, 76, 77, 72, 73, 67, 68,
-	69, 70, 71, 0, 0, 0, 0, 0, 103, 99,
-	0, 0, 101, 100, 102, 98, 11, 0, 8, 9,
-	10, 22, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 24, 0, 0, 11, 25, 8, 9,
-	10, 22, 0, 0, 0, 0, 0, 218, 0, 0,
-	0, 0, 0, 24, 0, 0, 0, 25, 0, 0,
-	0, 0, 0, 0, 0, 0, 14, 11, 0, 8,
-	9, 10, 22, 0, 0, 15, 16, 13, 0, 0,
-	0, 17, 18, 21, 24, 0, 14, 0, 25, 20,
-	19, 0, 23, 0, 0, 15, 16, 13, 0, 0,
-	0, 17, 18, 21, 0, 0, 0, 0, 0, 20,
-	19, 0, 23, 0, 0, 0, 0, 14, 0, 0,
-	0, 0, 0, 0, 0, 0, 15, 16, 13, 0,
-	0, 0, 17, 18, 21, 0, 0, 0, 0, 0,
-	20, 19, 53, 114, 0, 40, 58, 0, 0, 0,
-	231, 47, 39, 0, 120, 46, 0, 0, 0, 57,
-	42, 0, 43, 229, 0, 0, 0, 56, 0, 41,
-	44, 54, 51, 0, 37, 55, 52, 45, 0, 48,
-	59, 0, 0, 0, 0, 60, 61, 62, 63, 64,
-	363, 0, 0, 0, 53, 0, 0, 40, 58, 0,
-	0, 0, 0, 47, 39, 0, 120, 46, 0, 0,
-	0, 57, 42, 0, 43, 0, 0, 0, 0, 56,
-	0, 41, 44, 54, 51, 0, 37, 55, 52, 45,
-	0, 48, 59, 0, 0, 0, 0, 60, 61, 62,
-	63, 64, 53, 0, 0, 40, 58, 0, 0, 0,
-	0, 47, 39, 0, 120, 46, 0, 0, 0, 57,
-	42, 0, 43, 0, 0, 0, 0, 56, 0, 41,
-	44, 54, 51, 0, 37, 55, 52, 45, 0, 48,
-	59, 0, 0, 0, 0, 60, 61, 62, 63, 64,
-	53, 0, 0, 40, 58, 0, 0, 0, 0, 47,
-	0, 0, 120, 46, 0, 0, 0, 57, 42, 0,
-	43, 0, 0, 0, 0, 56, 0, 41, 44, 54,
-	0, 0, 0, 55, 0, 45, 0, 48, 59, 0,
-	0, 0, 0, 60, 61, 62, 63, 64,
+	24, 0, 0, 0, 27, 0, 0, 0, 0, 0,
+	0, 0, 25, 0, 0, 0, 26, 0, 0, 240,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 102, 0, 0, 0, 0, 14, 0,
+	0, 0, 0, 0, 0, 0, 0, 15, 16, 13,
+	0, 0, 0, 17, 18, 21, 0, 343, 344, 0,
+	102, 20, 19, 0, 23, 86, 85, 83, 84, 79,
+	80, 81, 82, 77, 78, 72, 73, 74, 75, 76,
+	0, 0, 0, 0, 0, 108, 104, 0, 0, 106,
+	105, 107, 103, 85, 83, 84, 79, 80, 81, 82,
+	77, 78, 72, 73, 74, 75, 76, 0, 0, 0,
+	0, 0, 108, 104, 0, 0, 106, 105, 107, 103,
+	11, 0, 8, 9, 10, 22, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 24, 0, 0,
+	11, 27, 8, 9, 10, 22, 0, 0, 0, 25,
+	297, 0, 0, 26, 0, 0, 240, 24, 0, 0,
+	11, 27, 8, 9, 10, 22, 0, 0, 0, 25,
+	259, 0, 0, 26, 0, 14, 0, 24, 0, 0,
+	0, 27, 0, 0, 15, 16, 13, 0, 0, 25,
+	17, 18, 21, 26, 0, 14, 0, 0, 20, 19,
+	0, 23, 0, 0, 15, 16, 13, 0, 102, 0,
+	17, 18, 21, 0, 0, 14, 0, 0, 20, 19,
+	0, 23, 0, 0, 15, 16, 13, 0, 0, 0,
+	17, 18, 21, 0, 0, 0, 0, 0, 20, 19,
+	0, 23, 83, 84, 79, 80, 81, 82, 77, 78,
+	72, 73, 74, 75, 76, 0, 0, 0, 0, 0,
+	108, 104, 0, 0, 106, 105, 107, 103, 11, 102,
+	8, 9, 10, 22, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 24, 0, 0, 0, 27,
+	0, 0, 0, 0, 0, 0, 0, 25, 0, 0,
+	0, 26, 0, 0, 84, 79, 80, 81, 82, 77,
+	78, 72, 73, 74, 75, 76, 0, 0, 0, 0,
+	0, 108, 104, 14, 0, 106, 105, 107, 103, 0,
+	0, 0, 15, 16, 13, 0, 0, 0, 17, 18,
+	21, 0, 0, 0, 0, 0, 20, 19, 11, 23,
+	8, 9, 10, 22, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 24, 0, 0, 11, 27,
+	8, 9, 10, 22, 0, 0, 0, 25, 0, 0,
+	0, 26, 0, 0, 0, 24, 0, 0, 0, 27,
+	0, 0, 0, 0, 0, 0, 0, 25, 0, 0,
+	0, 26, 0, 14, 240, 0, 0, 0, 0, 0,
+	0, 0, 15, 16, 13, 0, 0, 0, 17, 18,
+	21, 0, 0, 0, 0, 0, 20, 19, 0, 119,
+	0, 0, 0, 0, 0, 0, 0, 0, 17, 18,
+	21, 0, 0, 0, 0, 0, 20, 19, 57, 23,
+	0, 44, 63, 0, 0, 0, 257, 51, 43, 0,
+	125, 50, 0, 0, 0, 61, 46, 0, 47, 255,
+	0, 0, 0, 60, 0, 45, 48, 58, 55, 0,
+	39, 59, 56, 49, 0, 52, 64, 0, 0, 0,
+	0, 65, 66, 67, 68, 69, 430, 42, 40, 62,
+	57, 0, 0, 44, 63, 0, 0, 0, 0, 51,
+	43, 0, 125, 50, 0, 0, 0, 61, 46, 0,
+	47, 0, 0, 0, 0, 60, 0, 45, 48, 58,
+	55, 0, 39, 59, 56, 49, 0, 52, 64, 0,
+	0, 0, 0, 65, 66, 67, 68, 69, 0, 42,
+	40, 62, 57, 0, 0, 44, 63, 0, 0, 0,
+	0, 51, 43, 0, 125, 50, 0, 0, 0, 61,
+	46, 0, 47, 279, 0, 0, 0, 60, 0, 45,
+	48, 58, 55, 0, 39, 59, 56, 49, 0, 52,
+	64, 0, 0, 0, 0, 65, 66, 67, 68, 69,
+	0, 42, 40, 62, 57, 0, 0, 44, 63, 0,
+	0, 0, 0, 51, 43, 0, 125, 50, 0, 0,
+	0, 61, 46, 0, 47, 0, 0, 0, 0, 60,
+	0, 45, 48, 58, 55, 0, 39, 59, 56, 49,
+	0, 52, 64, 0, 0, 0, 0, 65, 66, 67,
+	68, 69, 0, 42, 40, 62, 57, 0, 0, 44,
+	63, 0, 0, 0, 0, 51, 0, 0, 125, 50,
+	0, 0, 0, 61, 46, 0, 47, 0, 0, 0,
+	0, 60, 0, 45, 48, 58, 0, 0, 284, 59,
+	0, 49, 0, 52, 64, 0, 0, 0, 0, 65,
+	66, 67, 68, 69, 0, 57, 145, 62, 44, 63,
+	0, 0, 0, 0, 51, 0, 0, 125, 50, 0,
+	0, 0, 61, 46, 0, 47, 0, 0, 0, 0,
+	60, 0, 45, 48, 58, 0, 0, 0, 59, 0,
+	49, 0, 52, 64, 0, 0, 0, 0, 65, 66,
+	67, 68, 69, 0, 0, 145, 62,
 }
-var yyPact = []int{
-
-	4, -1000, -1000, 1328, 942, -42, 194, 707, -1000, -1000,
-	-1000, -1000, 222, 1328, 1328, 1328, 1328, 1328, 1328, 1328,
-	1328, 1359, 44, 378, 42, -1000, -1000, -1000, 41, -1000,
-	-1000, 221, 71, 1553, 1205, 1601, -1000, -1000, 243, 243,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, 1328, 1328, 1328, 1328,
-	1328, 1328, 1328, 1328, 1328, 1328, 1328, 1328, 1328, 1328,
-	1328, 1328, 1328, 1328, 1328, 1328, 1328, 1328, 1328, 1328,
-	1328, 1328, 1328, 1328, 1328, 1328, 1328, 1328, 1328, 1328,
-	-1000, -1000, 243, 243, -1000, 144, 144, 144, 144, 144,
-	144, 144, 144, 144, 378, 1553, 109, 108, 22, -1000,
-	-1000, 1328, 251, 207, -48, 68, 201, -1000, 310, 71,
-	-1000, -1000, -1000, 1205, 1601, -1000, -1000, 1205, -1000, 1601,
-	-1000, -1000, -1000, -1000, 206, -1000, 204, 707, 230, 230,
-	144, 144, 144, 348, 348, 67, 67, 67, 67, 1231,
-	504, 1204, 1101, 1059, 1017, 975, 153, 707, 707, 707,
-	707, 707, 707, 707, 707, 707, 707, 707, 217, 194,
-	107, 120, -1000, -1000, 105, 190, 1308, -1000, 69, 310,
-	40, 22, 663, 103, -1000, -1000, 1453, 1328, 1308, 1553,
-	71, 71, 310, -1000, 32, -1000, -1000, -1000, 1553, 248,
-	1328, 39, -1000, -1000, 88, 1328, 144, -1000, -44, 1328,
-	22, 1453, 63, 1553, -1000, 794, 98, 189, -1000, -1000,
-	73, -1000, 119, 707, -1000, 707, -1000, 203, -1000, 71,
-	-1000, 68, 1, -1000, -1000, 891, -1000, 71, 178, -1000,
-	166, 932, 1328, 618, -1000, 1178, 112, 69, 97, -1000,
-	96, -1000, -1000, 1453, 69, 1, 310, 73, -1000, -1000,
-	-1000, -50, -1000, -1000, -51, 169, -1000, 1, 151, -1000,
-	-45, 248, -1000, -1000, 1328, 93, -1000, 0, -1000, 134,
-	-1000, 243, 1328, -1000, -1000, -1000, -1000, 73, -1000, -1000,
-	-1000, 71, 1328, -1000, -1000, 707, -1000, -1000, -46, 1308,
-	-1000, -1000, -1000, 574, 842, -1000, 707, -1000, -1000, -1000,
-	-1000, -1000, -1000, 525, -1000, -1000, -1000, 37, 35, -1000,
-	-52, -1000, -53, -54, -1000, 31, 243, 30, 1328, 18,
-	11, 1328, 150, 100, 1328, 1328, -1000, 1505, -1000, -1000,
-	224, 1328, -55, 1328, -56, -1000, 1328, 1328, 424, -1000,
-	-1000, 91, 87, -1000, -37, -59, -1000, 85, -1000, 84,
-	79, -1000, -60, -61, 1328, 1328, -1000, -1000, -1000, -1000,
-	-1000, 74, -62, 209, -1000, -1000, -63, 1328, -1000, -1000,
-	51, -1000, -1000, -1000,
+
+var yyPact = [...]int16{
+	16, -32768, -32768, 1740, 1293, 12, 201, 929, -32768, -32768,
+	-32768, -32768, 252, 1740, 1740, 1740, 1740, 1740, 1740, 1740,
+	1740, 1820, 64, 445, 63, 61, 142, -32768, -32768, -32768,
+	60, -32768, -32768, 247, 95, 2095, 373, 2196, -32768, -32768,
+	56, 295, 295, 270, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, 1740, 1740, 1740, 1740, 1740, 1740, 1740, 1740, 1740,
+	1740, 1740, 1740, 1740, 1740, 1740, 1740, 1740, 1740, 1740,
+	1740, 1740, 1740, 1740, 1740, 1740, 1740, 1740, 1740, 1740,
+	1740, 1740, 1740, 1740, 1740, -32768, -32768, 295, 295, -32768,
+	73, 73, 73, 73, 73, 73, 73, 73, 73, 445,
+	2095, 115, 114, 36, -32768, -32768, 1740, 445, 2095, 290,
+	229, -44, 68, 204, -32768, 263, 95, -32768, -32768, -32768,
+	373, 2196, -32768, -32768, 373, -32768, -32768, 2196, -32768, -32768,
+	-32768, 2095, 171, 228, 170, 167, 225, 295, 929, 13,
+	13, 73, 73, 73, 1344, 1344, 418, 418, 418, 418,
+	1719, 528, 1658, 1510, 1483, 1373, 1326, 169, 929, 929,
+	929, 929, 929, 929, 929, 929, 929, 929, 929, 244,
+	201, 112, 118, -32768, -32768, 107, 199, 1592, -32768, 121,
+	263, 53, 36, 882, 106, 120, -32768, 136, 104, -32768,
+	-32768, 1939, 1632, 1592, 2095, 95, 95, 263, -32768, 30,
+	-32768, -32768, -32768, 102, 300, 2043, 220, 300, 217, 2147,
+	275, 167, 1740, 48, -32768, -32768, 1840, 1740, 73, -32768,
+	-1, 1612, 36, 1939, 81, 2095, -32768, -32768, 445, 47,
+	-32768, 1185, 101, 198, -32768, -32768, 99, -32768, 117, 1740,
+	929, -32768, 929, -32768, 211, -32768, 95, -32768, 68, 40,
+	-32768, -32768, -32768, 187, -32768, -32768, 295, 1129, -32768, 166,
+	95, 2043, 193, 275, -32768, 2196, 188, -32768, 184, 206,
+	-32768, 1278, 1740, 835, -32768, 1455, 74, 1740, 121, 100,
+	-32768, 98, 89, -32768, 1740, -32768, -32768, 1939, 121, 40,
+	263, 99, -32768, 788, -32768, -32768, -45, 2043, 300, -32768,
+	-32768, -32768, -32768, -47, 189, -32768, 40, 163, -32768, 1073,
+	188, -2, 275, -32768, -32768, 1740, 275, 97, -32768, 45,
+	-32768, 134, -32768, 295, 1740, -32768, 741, -32768, -32768, -32768,
+	694, -32768, 99, -32768, -32768, -32768, 1021, -32768, -32768, 95,
+	1740, -32768, -8, -32768, -32768, 929, 188, -32768, -32768, -41,
+	1592, -32768, -32768, -32768, 647, -32768, -32768, 1237, -32768, -32768,
+	929, -32768, -42, -32768, -32768, -32768, -32768, -32768, -32768, 549,
+	-32768, -32768, -32768, -32768, 43, 42, -32768, -48, -32768, -49,
+	-50, -32768, 41, 295, 39, 1740, 37, 35, 1740, 154,
+	153, 1740, 1740, -32768, 1991, -32768, -32768, 254, 1740, -52,
+	1740, -53, -32768, 1740, 1740, 600, -32768, -32768, 96, 93,
+	-32768, 22, -54, -32768, 92, -32768, 87, 85, -32768, -55,
+	-56, 1740, 1740, -32768, -32768, -32768, -32768, -32768, 82, -57,
+	231, -32768, -32768, -58, 1740, -32768, -32768, 69, -32768, -32768,
+	-32768,
 }
-var yyPgo = []int{
-
-	0, 9, 347, 24, 346, 14, 37, 345, 344, 33,
-	39, 342, 341, 28, 339, 338, 12, 10, 336, 329,
-	1, 36, 27, 4, 326, 325, 26, 32, 35, 324,
-	29, 8, 322, 38, 313, 308, 306, 11, 303, 302,
-	6, 0, 5, 3, 300, 30, 104, 66, 40, 276,
-	52, 44, 299, 41, 298, 25, 293, 2, 291, 34,
-	13, 275, 290, 289, 287, 283, 282,
+
+var yyPgo = [...]int16{
+	0, 14, 393, 26, 392, 24, 53, 391, 390, 36,
+	52, 389, 388, 12, 32, 29, 10, 11, 385, 384,
+	1, 41, 27, 4, 383, 380, 38, 33, 44, 379,
+	37, 7, 377, 42, 375, 374, 373, 13, 368, 364,
+	6, 0, 2, 5, 361, 28, 117, 68, 35, 309,
+	55, 54, 43, 46, 359, 34, 358, 3, 356, 39,
+	30, 307, 25, 40, 352, 335, 50, 333, 332, 329,
+	327, 9, 323,
 }
-var yyR1 = []int{
 
-	0, 62, 62, 10, 10, 10, 22, 20, 20, 20,
+var yyR1 = [...]int8{
+	0, 68, 68, 10, 10, 10, 22, 20, 20, 20,
 	20, 20, 20, 20, 20, 20, 20, 20, 20, 20,
 	20, 20, 20, 20, 20, 20, 20, 20, 20, 20,
 	20, 20, 20, 20, 20, 20, 20, 20, 20, 20,
 	20, 20, 20, 20, 20, 20, 20, 20, 20, 20,
 	20, 20, 20, 20, 20, 20, 20, 20, 20, 20,
-	20, 20, 42, 42, 42, 63, 40, 35, 35, 35,
-	41, 39, 39, 39, 39, 39, 39, 39, 39, 39,
-	39, 39, 39, 39, 39, 39, 39, 1, 1, 1,
-	2, 2, 2, 16, 16, 16, 16, 16, 3, 3,
-	3, 3, 28, 28, 44, 44, 44, 44, 44, 44,
-	45, 45, 45, 45, 45, 45, 45, 46, 46, 46,
-	46, 46, 46, 46, 46, 46, 47, 47, 48, 48,
-	61, 57, 57, 57, 57, 57, 60, 59, 6, 12,
-	11, 11, 11, 64, 4, 43, 43, 58, 58, 17,
-	17, 13, 61, 61, 37, 20, 20, 61, 61, 5,
-	24, 31, 31, 33, 33, 33, 34, 34, 32, 32,
-	37, 66, 66, 65, 65, 38, 38, 49, 49, 23,
-	23, 21, 21, 26, 26, 27, 27, 7, 7, 36,
-	36, 8, 8, 9, 9, 29, 29, 30, 30, 54,
-	54, 55, 55, 50, 50, 51, 51, 52, 52, 53,
-	53, 18, 18, 19, 19, 14, 14, 25, 25, 15,
-	15, 56, 56,
+	20, 20, 20, 20, 20, 20, 42, 42, 42, 69,
+	40, 35, 35, 35, 41, 39, 39, 39, 39, 39,
+	39, 39, 39, 39, 39, 39, 39, 39, 39, 39,
+	39, 1, 1, 1, 2, 2, 2, 2, 16, 16,
+	16, 16, 16, 16, 3, 3, 3, 3, 28, 28,
+	44, 44, 44, 44, 44, 44, 44, 45, 45, 45,
+	45, 45, 45, 45, 45, 46, 46, 46, 46, 46,
+	46, 46, 46, 46, 47, 47, 48, 48, 61, 61,
+	57, 57, 57, 57, 57, 60, 59, 6, 12, 11,
+	11, 11, 70, 4, 43, 43, 58, 58, 17, 17,
+	13, 13, 61, 61, 61, 61, 61, 67, 67, 66,
+	66, 62, 62, 37, 20, 20, 61, 61, 61, 61,
+	61, 64, 64, 63, 63, 5, 24, 31, 31, 33,
+	33, 33, 34, 34, 32, 32, 37, 72, 72, 71,
+	71, 38, 38, 49, 49, 23, 23, 21, 21, 26,
+	26, 65, 65, 27, 27, 7, 7, 36, 36, 8,
+	8, 9, 9, 29, 29, 30, 30, 54, 54, 55,
+	55, 50, 50, 51, 51, 52, 52, 53, 53, 18,
+	18, 19, 19, 14, 14, 25, 25, 15, 15, 56,
+	56,
 }
-var yyR2 = []int{
 
+var yyR2 = [...]int8{
 	0, 3, 3, 0, 2, 5, 1, 1, 1, 1,
 	1, 1, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	5, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 2, 2, 2, 2, 2, 2, 2, 2,
 	2, 4, 6, 4, 4, 3, 7, 4, 4, 2,
-	2, 6, 0, 2, 2, 0, 4, 3, 2, 2,
-	2, 1, 5, 5, 1, 2, 3, 2, 2, 7,
-	9, 3, 5, 7, 3, 5, 5, 0, 3, 1,
-	4, 4, 3, 1, 3, 3, 4, 4, 1, 2,
-	2, 1, 1, 3, 1, 1, 1, 1, 1, 1,
+	2, 6, 4, 4, 6, 7, 0, 2, 2, 0,
+	4, 3, 2, 2, 2, 1, 5, 5, 1, 2,
+	3, 2, 2, 7, 9, 3, 5, 7, 3, 5,
+	5, 0, 3, 1, 4, 4, 5, 3, 1, 3,
+	3, 4, 4, 5, 1, 2, 2, 1, 1, 3,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 1, 3, 3, 2, 2, 1, 2, 3, 3,
-	1, 1, 5, 0, 5, 1, 1, 1, 1, 1,
-	3, 3, 2, 5, 2, 3, 3, 2, 6, 2,
-	2, 1, 1, 2, 4, 5, 0, 3, 1, 3,
-	3, 0, 1, 0, 1, 1, 2, 0, 1, 0,
-	1, 0, 1, 1, 3, 0, 1, 0, 2, 0,
-	2, 1, 3, 0, 1, 1, 3, 0, 1, 1,
-	2, 0, 1, 1, 2, 0, 1, 1, 2, 0,
-	1, 1, 3, 0, 1, 1, 2, 0, 1, 1,
-	3, 1, 2,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 4,
+	1, 3, 3, 2, 2, 1, 2, 3, 3, 1,
+	1, 5, 0, 5, 1, 1, 1, 1, 1, 3,
+	2, 3, 2, 5, 7, 2, 6, 0, 2, 1,
+	3, 1, 2, 2, 3, 3, 2, 6, 7, 3,
+	8, 0, 1, 2, 2, 2, 2, 1, 1, 2,
+	4, 5, 0, 3, 1, 3, 3, 0, 1, 0,
+	1, 1, 2, 0, 1, 0, 1, 0, 1, 1,
+	3, 1, 3, 0, 1, 0, 2, 0, 2, 1,
+	3, 0, 1, 1, 3, 0, 1, 1, 2, 0,
+	1, 1, 2, 0, 1, 1, 2, 0, 1, 1,
+	3, 0, 1, 1, 2, 0, 1, 1, 3, 1,
+	2,
 }
-var yyChk = []int{
-
-	-1000, -62, 105, 106, -10, -22, -26, -20, 30, 31,
-	32, 28, -56, 89, 78, 87, 88, 93, 94, 102,
-	101, 95, 33, 104, 45, 49, 107, -11, 6, -12,
-	-4, 21, -57, -50, -61, -46, -47, 41, -58, 19,
-	12, 36, 27, 29, 37, 44, 22, 18, 46, -44,
-	-45, 39, 43, 9, 38, 42, 34, 26, 13, 47,
-	52, 53, 54, 55, 56, 107, 60, 87, 88, 89,
-	90, 91, 85, 86, 81, 82, 83, 84, 79, 80,
-	78, 77, 76, 75, 74, 72, 61, 62, 63, 64,
-	65, 66, 67, 68, 69, 70, 71, 50, 104, 98,
-	102, 101, 103, 97, 49, -20, -20, -20, -20, -20,
-	-20, -20, -20, -20, 104, 104, -59, -22, -60, -57,
-	21, 104, 104, 49, -30, -16, -29, -43, 89, 104,
-	-28, 30, 41, -61, -46, -47, -51, -50, -53, -52,
-	-48, -47, -46, -43, -49, -43, -49, -20, -20, -20,
+
+var yyChk = [...]int16{
+	-32768, -68, 110, 111, -10, -22, -26, -20, 30, 31,
+	32, 28, -56, 94, 83, 92, 93, 98, 99, 107,
+	106, 100, 33, 109, 45, 57, 61, 49, 112, -11,
+	6, -12, -4, 21, -57, -50, -61, -46, -47, 41,
+	59, -58, 58, 19, 12, 36, 27, 29, 37, 44,
+	22, 18, 46, -44, -45, 39, 43, 9, 38, 42,
+	34, 26, 60, 13, 47, 52, 53, 54, 55, 56,
+	112, 65, 92, 93, 94, 95, 96, 90, 91, 86,
+	87, 88, 89, 84, 85, 83, 82, 81, 80, 79,
+	77, 66, 67, 68, 69, 70, 71, 72, 73, 74,
+	75, 76, 50, 109, 103, 107, 106, 108, 102, 49,
+	-20, -20, -20, -20, -20, -20, -20, -20, -20, 109,
+	109, -59, -22, -60, -57, 21, 109, 109, 86, 109,
+	49, -30, -16, -29, -43, 94, 109, -28, 30, 41,
+	-61, -46, -47, -51, -50, 59, -53, -52, -48, -47,
+	-46, 109, -43, -49, -43, -43, -49, 58, -20, -20,
 	-20, -20, -20, -20, -20, -20, -20, -20, -20, -20,
-	-20, -20, -20, -20, -20, -20, -22, -20, -20, -20,
-	-20, -20, -20, -20, -20, -20, -20, -20, -27, -26,
-	-27, -22, -43, -43, -59, -59, 100, 100, -1, 89,
-	-2, 104, -20, 30, 59, 109, 104, 98, 61, -7,
-	60, -55, -54, -45, -16, -51, -53, -48, 59, 59,
-	73, 51, 100, 99, 100, 60, -20, -33, 59, 98,
-	-55, 104, -1, 60, 100, -10, -9, -8, -3, 30,
-	-60, 17, -21, -20, -31, -20, -33, -64, -6, -57,
-	-28, -16, -16, -45, 100, -14, -13, -60, -15, -5,
-	30, -20, 104, -20, 108, -34, -21, -1, -9, 100,
-	-59, 108, 100, 60, -1, -16, 89, 104, 99, -40,
-	59, -30, 108, -13, -19, -18, -17, -16, -49, -43,
-	-65, 60, -25, -24, 61, -27, 100, -32, -31, -38,
-	-37, 97, 98, 99, 100, 100, -3, -55, -63, 109,
-	109, 60, 73, 108, -5, -20, 100, 108, 60, -66,
-	-37, 61, -43, -20, -42, -17, -20, 108, -31, 99,
-	-6, -41, 108, -36, -39, -35, 109, 8, 7, -40,
-	-22, 4, 10, 14, 16, 23, 24, 25, 35, 40,
-	48, 11, 15, 30, 104, 104, 109, -42, 109, 109,
-	-41, 104, -43, 104, -23, -22, 104, 104, -20, 73,
-	73, -22, -22, 5, 48, -23, 109, -22, 109, -22,
-	-22, 73, 100, 100, 104, 109, 100, 100, 100, 109,
-	109, -22, -23, -41, -41, -41, 100, 109, 58, 109,
-	-23, -41, 100, -41,
+	-20, -20, -20, -20, -20, -20, -20, -22, -20, -20,
+	-20, -20, -20, -20, -20, -20, -20, -20, -20, -27,
+	-26, -27, -22, -43, -43, -59, -59, 105, 105, -1,
+	94, -2, 109, -20, -27, -65, -59, -59, 30, 64,
+	114, 109, 103, 66, -7, 65, -55, -54, -45, -16,
+	-51, -53, -48, -59, 78, 64, -67, 78, -63, 78,
+	64, -43, 78, 51, 105, 104, 105, 65, -20, -33,
+	64, 103, -55, 109, -1, 65, 105, 105, 65, 87,
+	105, -10, -9, -8, -3, 30, -60, 17, -21, 38,
+	-20, -31, -20, -33, -70, -6, -57, -28, -16, -16,
+	-45, 105, 105, -66, -62, -43, 30, -14, -13, 30,
+	-60, 64, -66, 64, 41, -52, -15, -5, 30, -64,
+	-63, -20, 109, -20, 113, -34, -21, 38, -1, -9,
+	105, -59, -26, -59, 109, 113, 105, 65, -1, -16,
+	94, 109, 104, -20, -40, 64, -30, 64, 65, -43,
+	113, -13, 78, -19, -18, -17, -16, -49, -43, -14,
+	-15, -71, 65, -25, -24, 66, 64, -27, 105, -32,
+	-31, -38, -37, 102, 103, 104, -20, 105, 105, 105,
+	-20, -3, -55, 104, -69, 114, -14, -62, 114, 65,
+	78, 113, -71, 113, -5, -20, -15, 105, 113, 65,
+	-72, -37, 66, -43, -20, 104, 105, -42, 113, -17,
+	-20, 113, -71, 113, -31, 104, -6, -41, 113, -36,
+	113, -39, -35, 114, 8, 7, -40, -22, 4, 10,
+	14, 16, 23, 24, 25, 35, 40, 48, 11, 15,
+	30, 109, 109, 114, -42, 114, 114, -41, 109, -43,
+	109, -23, -22, 109, 109, -20, 78, 78, -22, -22,
+	5, 48, -23, 114, -22, 114, -22, -22, 78, 105,
+	105, 109, 114, 105, 105, 105, 114, 114, -22, -23,
+	-41, -41, -41, 105, 114, 63, 114, -23, -41, 105,
+	-41,
 }
-var yyDef = []int{
 
-	0, -2, 3, 0, 0, 0, 6, 183, 7, 8,
+var yyDef = [...]int16{
+	0, -2, 3, 0, 0, 0, 6, 209, 7, 8,
 	9, 10, 11, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 221, 1, 4, 0, 140,
-	141, 106, 197, 131, 205, 209, 203, 130, 177, 177,
-	117, 118, 119, 120, 121, 122, 123, 124, 125, 126,
-	127, 147, 148, 104, 105, 107, 108, 109, 110, 111,
-	112, 113, 114, 115, 116, 2, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 249, 1, 4,
+	0, 149, 150, 112, 225, 140, 233, 237, 231, 138,
+	119, 203, 0, 203, 125, 126, 127, 128, 129, 130,
+	131, 132, 133, 134, 135, 156, 157, 110, 111, 113,
+	114, 115, 116, 117, 118, 120, 121, 122, 123, 124,
+	2, 0, 0, 0, 0, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 185, 185, 0,
-	59, 60, 0, 0, 222, 42, 43, 44, 45, 46,
-	47, 48, 49, 50, 0, 0, 0, 0, 87, 136,
-	106, 0, 0, 0, 0, -2, 198, 93, 201, 0,
-	195, 145, 146, 205, 209, 204, 134, 206, 135, 210,
-	207, 128, 129, -2, 0, -2, 0, 184, 12, 13,
-	14, 15, 16, 17, 18, 19, 20, 21, 22, 23,
-	24, 25, 26, 27, 28, 29, 0, 31, 32, 33,
-	34, 35, 36, 37, 38, 39, 40, 41, 0, 186,
-	0, 0, 155, 156, 0, 0, 0, 55, 137, 201,
-	89, 87, 0, 0, 3, 139, 193, 181, 0, 143,
-	0, 0, 202, 199, 0, 132, 133, 208, 0, 0,
-	0, 0, 57, 58, 51, 0, 53, 54, 166, 181,
-	87, 193, 0, 0, 5, 0, 0, 194, 191, 98,
-	87, 101, 0, 182, 103, 161, 162, 0, 188, 197,
-	196, 102, 94, 200, 95, 0, 215, -2, 173, 219,
-	217, 30, 185, 0, 163, 0, 0, 88, 0, 92,
-	0, 142, 96, 0, 99, 100, 201, 87, 97, 144,
-	65, 0, 153, 216, 0, 214, 211, 149, 0, -2,
-	0, 174, 159, 218, 0, 0, 52, 0, 168, 171,
-	175, 0, 0, 91, 90, 61, 192, 87, 62, 138,
-	151, 177, 0, 158, 220, 160, 56, 164, 167, 0,
-	176, 172, 154, 0, 189, 212, 150, 165, 169, 170,
-	63, 64, 66, 0, 70, 190, 71, 0, 0, 74,
-	0, 62, 0, 0, 189, 0, 0, 0, 179, 0,
-	0, 0, 0, 7, 0, 0, 75, 189, 77, 78,
-	0, 179, 0, 0, 0, 180, 0, 0, 0, 68,
-	69, 0, 0, 76, 0, 0, 81, 0, 84, 0,
-	0, 67, 0, 0, 0, 179, 189, 189, 189, 72,
-	73, 0, 0, 82, 85, 86, 0, 179, 189, 79,
-	0, 83, 189, 80,
+	0, 0, 213, 213, 0, 59, 60, 0, 0, 250,
+	42, 43, 44, 45, 46, 47, 48, 49, 50, 0,
+	0, 0, 0, 91, 145, 112, 0, 213, 0, 0,
+	0, 0, -2, 226, 98, 229, 0, 223, 154, 155,
+	233, 237, 232, 143, 234, 119, 144, 238, 235, 136,
+	137, 0, -2, 0, -2, -2, 0, 0, 210, 12,
+	13, 14, 15, 16, 17, 18, 19, 20, 21, 22,
+	23, 24, 25, 26, 27, 28, 29, 0, 31, 32,
+	33, 34, 35, 36, 37, 38, 39, 40, 41, 0,
+	214, 0, 0, 174, 175, 0, 0, 0, 55, 146,
+	229, 93, 91, 0, 0, 0, 211, 0, 0, 3,
+	148, 221, 207, 0, 152, 0, 0, 230, 227, 0,
+	141, 142, 236, 0, 0, 0, 0, 0, 0, 0,
+	0, -2, 0, 0, 57, 58, 51, 0, 53, 54,
+	192, 207, 91, 221, 0, 0, 62, 63, 0, 0,
+	5, 0, 0, 222, 219, 104, 91, 107, 0, 0,
+	208, 109, 187, 188, 0, 216, 225, 224, 108, 99,
+	228, 100, 139, 0, 169, 171, 154, 0, 243, 0,
+	-2, 0, 168, 0, 183, 184, 199, 247, 245, 0,
+	182, 30, 213, 0, 189, 0, 0, 0, 92, 0,
+	97, 0, 0, 212, 0, 151, 101, 0, 105, 106,
+	229, 91, 102, 0, 153, 69, 0, 0, 0, 172,
+	163, 244, 160, 0, 242, 239, 158, 0, -2, 0,
+	199, 0, 200, 185, 246, 0, 0, 0, 52, 0,
+	194, 197, 201, 0, 0, 95, 0, 94, 61, 64,
+	0, 220, 91, 103, 66, 147, 0, 170, 161, 203,
+	0, 166, 0, 177, 248, 186, 199, 56, 190, 193,
+	0, 202, 198, 173, 0, 96, 65, 217, 164, 240,
+	159, 178, 0, 191, 195, 196, 67, 68, 70, 0,
+	180, 74, 218, 75, 0, 0, 78, 0, 66, 0,
+	0, 217, 0, 0, 0, 205, 0, 0, 0, 0,
+	7, 0, 0, 79, 217, 81, 82, 0, 205, 0,
+	0, 0, 206, 0, 0, 0, 72, 73, 0, 0,
+	80, 0, 0, 85, 0, 88, 0, 0, 71, 0,
+	0, 0, 205, 217, 217, 217, 76, 77, 0, 0,
+	86, 89, 90, 0, 205, 217, 83, 0, 87, 217,
+	84,
 }
-var yyTok1 = []int{
 
+var yyTok1 = [...]int8{
 	1, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 93, 3, 3, 3, 91, 78, 3,
-	104, 100, 89, 87, 60, 88, 97, 90, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 3, 73, 109,
-	81, 61, 82, 72, 3, 3, 3, 3, 3, 3,
+	3, 3, 3, 98, 3, 3, 3, 96, 83, 3,
+	109, 105, 94, 92, 65, 93, 102, 95, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 78, 114,
+	86, 66, 87, 77, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 98, 3, 99, 77, 3, 3, 3, 3, 3,
+	3, 103, 3, 104, 82, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 59, 76, 108, 94,
+	3, 3, 3, 64, 81, 113, 99,
 }
-var yyTok2 = []int{
 
+var yyTok2 = [...]int8{
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
 	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
 	22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
 	32, 33, 34, 35, 36, 37, 38, 39, 40, 41,
 	42, 43, 44, 45, 46, 47, 48, 49, 50, 51,
-	52, 53, 54, 55, 56, 57, 58, 62, 63, 64,
-	65, 66, 67, 68, 69, 70, 71, 74, 75, 79,
-	80, 83, 84, 85, 86, 92, 95, 96, 101, 102,
-	103, 105, 106, 107,
+	52, 53, 54, 55, 56, 57, 58, 59, 60, 61,
+	62, 63, 67, 68, 69, 70, 71, 72, 73, 74,
+	75, 76, 79, 80, 84, 85, 88, 89, 90, 91,
+	97, 100, 101, 106, 107, 108, 110, 111, 112,
 }
-var yyTok3 = []int{
+
+var yyTok3 = [...]int8{
 	0,
 }
 
+var yyErrorMessages = [...]struct {
+	state int
+	token int
+	msg   string
+}{}
+
 //line yaccpar:1
 
 /*	parser for yacc output	*/
 
-var yyDebug = 0
+var (
+	yyDebug        = 0
+	yyErrorVerbose = false
+)
 
 type yyLexer interface {
 	Lex(lval *yySymType) int
 	Error(s string)
 }
 
-const yyFlag = -1000
+type yyParser interface {
+	Parse(yyLexer) int
+	Lookahead() int
+}
+
+type yyParserImpl struct {
+	lval  yySymType
+	stack [yyInitialStackSize]yySymType
+	char  int
+}
+
+func (p *yyParserImpl) Lookahead() int {
+	return p.char
+}
+
+func yyNewParser() yyParser {
+	return &yyParserImpl{}
+}
+
+const yyFlag = -32768
 
 func yyTokname(c int) string {
-	// 4 is TOKSTART above
-	if c >= 4 && c-4 < len(yyToknames) {
-		if yyToknames[c-4] != "" {
-			return yyToknames[c-4]
+	if c >= 1 && c-1 < len(yyToknames) {
+		if yyToknames[c-1] != "" {
+			return yyToknames[c-1]
 		}
 	}
 	return __yyfmt__.Sprintf("tok-%v", c)
@@ -713,51 +850,127 @@ func yyStatname(s int) string {
 	return __yyfmt__.Sprintf("state-%v", s)
 }
 
-func yylex1(lex yyLexer, lval *yySymType) int {
-	c := 0
-	char := lex.Lex(lval)
+func yyErrorMessage(state, lookAhead int) string {
+	const TOKSTART = 4
+
+	if !yyErrorVerbose {
+		return "syntax error"
+	}
+
+	for _, e := range yyErrorMessages {
+		if e.state == state && e.token == lookAhead {
+			return "syntax error: " + e.msg
+		}
+	}
+
+	res := "syntax error: unexpected " + yyTokname(lookAhead)
+
+	// To match Bison, suggest at most four expected tokens.
+	expected := make([]int, 0, 4)
+
+	// Look for shiftable tokens.
+	base := int(yyPact[state])
+	for tok := TOKSTART; tok-1 < len(yyToknames); tok++ {
+		if n := base + tok; n >= 0 && n < yyLast && int(yyChk[int(yyAct[n])]) == tok {
+			if len(expected) == cap(expected) {
+				return res
+			}
+			expected = append(expected, tok)
+		}
+	}
+
+	if yyDef[state] == -2 {
+		i := 0
+		for yyExca[i] != -1 || int(yyExca[i+1]) != state {
+			i += 2
+		}
+
+		// Look for tokens that we accept or reduce.
+		for i += 2; yyExca[i] >= 0; i += 2 {
+			tok := int(yyExca[i])
+			if tok < TOKSTART || yyExca[i+1] == 0 {
+				continue
+			}
+			if len(expected) == cap(expected) {
+				return res
+			}
+			expected = append(expected, tok)
+		}
+
+		// If the default action is to accept or reduce, give up.
+		if yyExca[i+1] != 0 {
+			return res
+		}
+	}
+
+	for i, tok := range expected {
+		if i == 0 {
+			res += ", expecting "
+		} else {
+			res += " or "
+		}
+		res += yyTokname(tok)
+	}
+	return res
+}
+
+func yylex1(lex yyLexer, lval *yySymType) (char, token int) {
+	token = 0
+	char = lex.Lex(lval)
 	if char <= 0 {
-		c = yyTok1[0]
+		token = int(yyTok1[0])
 		goto out
 	}
 	if char < len(yyTok1) {
-		c = yyTok1[char]
+		token = int(yyTok1[char])
 		goto out
 	}
 	if char >= yyPrivate {
 		if char < yyPrivate+len(yyTok2) {
-			c = yyTok2[char-yyPrivate]
+			token = int(yyTok2[char-yyPrivate])
 			goto out
 		}
 	}
 	for i := 0; i < len(yyTok3); i += 2 {
-		c = yyTok3[i+0]
-		if c == char {
-			c = yyTok3[i+1]
+		token = int(yyTok3[i+0])
+		if token == char {
+			token = int(yyTok3[i+1])
 			goto out
 		}
 	}
 
 out:
-	if c == 0 {
-		c = yyTok2[1] /* unknown char */
+	if token == 0 {
+		token = int(yyTok2[1]) /* unknown char */
 	}
 	if yyDebug >= 3 {
-		__yyfmt__.Printf("lex %s(%d)\n", yyTokname(c), uint(char))
+		__yyfmt__.Printf("lex %s(%d)\n", yyTokname(token), uint(char))
 	}
-	return c
+	return char, token
 }
 
 func yyParse(yylex yyLexer) int {
+	return yyNewParser().Parse(yylex)
+}
+
+func (yyrcvr *yyParserImpl) Parse(yylex yyLexer) int {
 	var yyn int
-	var yylval yySymType
 	var yyVAL yySymType
-	yyS := make([]yySymType, yyMaxDepth)
+	var yyDollar []yySymType
+	_ = yyDollar // silence set and not used
+	yyS := yyrcvr.stack[:]
 
 	Nerrs := 0   /* number of errors */
 	Errflag := 0 /* error recovery flag */
 	yystate := 0
-	yychar := -1
+	yyrcvr.char = -1
+	yytoken := -1 // yyrcvr.char translated into internal numbering
+	defer func() {
+		// Make sure we report no lookahead when not parsing.
+		yystate = -1
+		yyrcvr.char = -1
+		yytoken = -1
+	}()
 	yyp := -1
 	goto yystack
 
@@ -770,7 +983,7 @@ ret1:
 yystack:
 	/* put a state and value onto the stack */
 	if yyDebug >= 4 {
-		__yyfmt__.Printf("char %v in %v\n", yyTokname(yychar), yyStatname(yystate))
+		__yyfmt__.Printf("char %v in %v\n", yyTokname(yytoken), yyStatname(yystate))
 	}
 
 	yyp++
@@ -783,21 +996,22 @@ yystack:
 	yyS[yyp].yys = yystate
 
 yynewstate:
-	yyn = yyPact[yystate]
+	yyn = int(yyPact[yystate])
 	if yyn <= yyFlag {
 		goto yydefault /* simple state */
 	}
-	if yychar < 0 {
-		yychar = yylex1(yylex, &yylval)
+	if yyrcvr.char < 0 {
+		yyrcvr.char, yytoken = yylex1(yylex, &yyrcvr.lval)
 	}
-	yyn += yychar
+	yyn += yytoken
 	if yyn < 0 || yyn >= yyLast {
 		goto yydefault
 	}
-	yyn = yyAct[yyn]
-	if yyChk[yyn] == yychar { /* valid shift */
-		yychar = -1
-		yyVAL = yylval
+	yyn = int(yyAct[yyn])
+	if int(yyChk[yyn]) == yytoken { /* valid shift */
+		yyrcvr.char = -1
+		yytoken = -1
+		yyVAL = yyrcvr.lval
 		yystate = yyn
 		if Errflag > 0 {
 			Errflag--
@@ -807,27 +1021,27 @@ yynewstate:
 
 yydefault:
 	/* default state action */
-	yyn = yyDef[yystate]
+	yyn = int(yyDef[yystate])
 	if yyn == -2 {
-		if yychar < 0 {
-			yychar = yylex1(yylex, &yylval)
+		if yyrcvr.char < 0 {
+			yyrcvr.char, yytoken = yylex1(yylex, &yyrcvr.lval)
 		}
 
 		/* look through exception table */
 		xi := 0
 		for {
-			if yyExca[xi+0] == -1 && yyExca[xi+1] == yystate {
+			if yyExca[xi+0] == -1 && int(yyExca[xi+1]) == yystate {
 				break
 			}
 			xi += 2
 		}
 		for xi += 2; ; xi += 2 {
-			yyn = yyExca[xi+0]
-			if yyn < 0 || yyn == yychar {
+			yyn = int(yyExca[xi+0])
+			if yyn < 0 || yyn == yytoken {
 				break
 			}
 		}
-		yyn = yyExca[xi+1]
+		yyn = int(yyExca[xi+1])
 		if yyn < 0 {
 			goto ret0
 		}
@@ -836,11 +1050,11 @@ yydefault:
 		/* error ... attempt to resume parsing */
 		switch Errflag {
 		case 0: /* brand new error */
-			yylex.Error("syntax error")
+			yylex.Error(yyErrorMessage(yystate, yytoken))
 			Nerrs++
 			if yyDebug >= 1 {
 				__yyfmt__.Printf("%s", yyStatname(yystate))
-				__yyfmt__.Printf(" saw %s\n", yyTokname(yychar))
+				__yyfmt__.Printf(" saw %s\n", yyTokname(yytoken))
 			}
 			fallthrough
 
@@ -849,10 +1063,10 @@ yydefault:
 
 			/* find a state where "error" is a legal shift action */
 			for yyp >= 0 {
-				yyn = yyPact[yyS[yyp].yys] + yyErrCode
+				yyn = int(yyPact[yyS[yyp].yys]) + yyErrCode
 				if yyn >= 0 && yyn < yyLast {
-					yystate = yyAct[yyn] /* simulate a shift of "error" */
-					if yyChk[yystate] == yyErrCode {
+					yystate = int(yyAct[yyn]) /* simulate a shift of "error" */
+					if int(yyChk[yystate]) == yyErrCode {
 						goto yystack
 					}
 				}
@@ -868,12 +1082,13 @@ yydefault:
 
 		case 3: /* no shift yet; clobber input char */
 			if yyDebug >= 2 {
-				__yyfmt__.Printf("error recovery discards %s\n", yyTokname(yychar))
+				__yyfmt__.Printf("error recovery discards %s\n", yyTokname(yytoken))
 			}
-			if yychar == yyEofCode {
+			if yytoken == yyEofCode {
 				goto ret1
 			}
-			yychar = -1
+			yyrcvr.char = -1
+			yytoken = -1
 			goto yynewstate /* try again in the same state */
 		}
 	}
@@ -887,618 +1102,752 @@ yydefault:
 	yypt := yyp
 	_ = yypt // guard against "declared and not used"
 
-	yyp -= yyR2[yyn]
+	yyp -= int(yyR2[yyn])
+	// yyp is now the index of $0. Perform the default action. Iff the
+	// reduced production is ε, $1 is possibly out of range.
+	if yyp+1 >= len(yyS) {
+		nyys := make([]yySymType, len(yyS)*2)
+		copy(nyys, yyS)
+		yyS = nyys
+	}
 	yyVAL = yyS[yyp+1]
 
 	/* consult goto table to find next state */
-	yyn = yyR1[yyn]
-	yyg := yyPgo[yyn]
+	yyn = int(yyR1[yyn])
+	yyg := int(yyPgo[yyn])
 	yyj := yyg + yyS[yyp].yys + 1
 
 	if yyj >= yyLast {
-		yystate = yyAct[yyg]
+		yystate = int(yyAct[yyg])
 	} else {
-		yystate = yyAct[yyj]
-		if yyChk[yystate] != -yyn {
-			yystate = yyAct[yyg]
+		yystate = int(yyAct[yyj])
+		if int(yyChk[yystate]) != -yyn {
+			yystate = int(yyAct[yyg])
 		}
 	}
 	// dummy call; replaced with literal code
 	switch yynt {
 
 	case 1:
-		//line cc.y:219
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:226
 		{
-			yylex.(*lexer).prog = &Prog{Decls: yyS[yypt-1].decls, Id: nextId()}
+			yylex.(*lexer).prog = &Prog{Decls: yyDollar[2].decls, Id: nextId()}
 			return 0
 		}
 	case 2:
-		//line cc.y:224
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:231
 		{
-			yylex.(*lexer).expr = yyS[yypt-1].expr
+			yylex.(*lexer).expr = yyDollar[2].expr
 			return 0
 		}
 	case 3:
-		//line cc.y:230
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line cc.y:237
 		{
 			yyVAL.span = Span{}
 			yyVAL.decls = nil
 		}
 	case 4:
-		//line cc.y:235
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:242
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.decls = append(yyS[yypt-1].decls, yyS[yypt-0].decls...)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.decls = append(yyDollar[1].decls, yyDollar[2].decls...)
+			yylex.(*lexer).timeDecls(yyDollar[2].decls)
 		}
 	case 5:
-		//line cc.y:240
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line cc.y:248
 		{
 		}
 	case 6:
-		//line cc.y:245
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:253
 		{
-			yyVAL.span = yyS[yypt-0].span
-			if len(yyS[yypt-0].exprs) == 1 {
-				yyVAL.expr = yyS[yypt-0].exprs[0]
+			yyVAL.span = yyDollar[1].span
+			if len(yyDollar[1].exprs) == 1 {
+				yyVAL.expr = yyDollar[1].exprs[0]
 				break
 			}
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Comma, List: yyS[yypt-0].exprs}
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Comma, List: yyDollar[1].exprs}
 		}
 	case 7:
-		//line cc.y:256
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:264
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.expr = &Expr{
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 				Id:         nextId(),
 				Op:         Name,
 				Text: &SymbolLiteral{
-					Value:      yyS[yypt-0].str,
+					Value:      yyDollar[1].str,
 					Id:         nextId(),
 					SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 				},
-				XDecl: yyS[yypt-0].decl,
+				XDecl: yyDollar[1].decl,
 			}
 		}
 	case 8:
-		//line cc.y:271
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:279
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.expr = &Expr{
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 				Id:         nextId(),
 				Op:         Literal,
-				Text:       yyS[yypt-0].intlit,
+				Text:       yyDollar[1].intlit,
 			}
 		}
 	case 9:
-		//line cc.y:281
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:289
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.expr = &Expr{
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 				Id:         nextId(),
 				Op:         Literal,
-				Text:       yyS[yypt-0].reallit,
+				Text:       yyDollar[1].reallit,
 			}
 		}
 	case 10:
-		//line cc.y:291
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:299
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.expr = &Expr{
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 				Id:         nextId(),
 				Op:         Literal,
 				Text: &CharLiteral{
-					Value:      yyS[yypt-0].str[0],
+					Value:      yyDollar[1].str[0],
 					Id:         nextId(),
 					SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 				}}
 		}
 	case 11:
-		//line cc.y:304
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:312
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: String, Texts: yyS[yypt-0].syntaxs}
+			yyVAL.span = yyDollar[1].span
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: String, Texts: yyDollar[1].syntaxs}
 		}
 	case 12:
-		//line cc.y:309
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:317
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Add, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Add, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 13:
-		//line cc.y:314
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:322
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Sub, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Sub, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 14:
-		//line cc.y:319
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:327
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Mul, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Mul, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 15:
-		//line cc.y:324
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:332
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Div, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Div, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 16:
-		//line cc.y:329
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:337
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Mod, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Mod, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 17:
-		//line cc.y:334
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:342
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Lsh, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Lsh, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 18:
-		//line cc.y:339
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:347
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Rsh, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Rsh, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 19:
-		//line cc.y:344
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:352
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Lt, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Lt, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 20:
-		//line cc.y:349
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:357
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Gt, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Gt, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 21:
-		//line cc.y:354
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:362
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: LtEq, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: LtEq, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 22:
-		//line cc.y:359
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:367
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: GtEq, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: GtEq, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 23:
-		//line cc.y:364
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:372
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: EqEq, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: EqEq, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 24:
-		//line cc.y:369
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:377
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: NotEq, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: NotEq, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 25:
-		//line cc.y:374
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:382
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: And, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: And, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 26:
-		//line cc.y:379
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:387
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Xor, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Xor, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 27:
-		//line cc.y:384
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:392
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Or, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Or, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 28:
-		//line cc.y:389
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:397
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: AndAnd, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: AndAnd, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 29:
-		//line cc.y:394
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:402
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: OrOr, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: OrOr, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 30:
-		//line cc.y:399
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line cc.y:407
 		{
-			yyVAL.span = span(yyS[yypt-4].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Cond, List: []*Expr{yyS[yypt-4].expr, yyS[yypt-2].expr, yyS[yypt-0].expr}}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[5].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Cond, List: []*Expr{yyDollar[1].expr, yyDollar[3].expr, yyDollar[5].expr}}
 		}
 	case 31:
-		//line cc.y:404
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:412
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Eq, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Eq, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 32:
-		//line cc.y:409
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:417
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: AddEq, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: AddEq, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 33:
-		//line cc.y:414
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:422
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: SubEq, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: SubEq, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 34:
-		//line cc.y:419
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:427
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: MulEq, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: MulEq, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 35:
-		//line cc.y:424
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:432
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: DivEq, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: DivEq, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 36:
-		//line cc.y:429
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:437
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: ModEq, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: ModEq, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 37:
-		//line cc.y:434
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:442
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: LshEq, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: LshEq, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 38:
-		//line cc.y:439
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:447
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: RshEq, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: RshEq, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 39:
-		//line cc.y:444
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:452
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: AndEq, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: AndEq, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 40:
-		//line cc.y:449
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:457
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: XorEq, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: XorEq, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 41:
-		//line cc.y:454
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:462
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: OrEq, Left: yyS[yypt-2].expr, Right: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: OrEq, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 42:
-		//line cc.y:459
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:467
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Indir, Left: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Indir, Left: yyDollar[2].expr}
 		}
 	case 43:
-		//line cc.y:464
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:472
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Addr, Left: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Addr, Left: yyDollar[2].expr}
 		}
 	case 44:
-		//line cc.y:469
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:477
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Plus, Left: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Plus, Left: yyDollar[2].expr}
 		}
 	case 45:
-		//line cc.y:474
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:482
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Minus, Left: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Minus, Left: yyDollar[2].expr}
 		}
 	case 46:
-		//line cc.y:479
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:487
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Not, Left: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Not, Left: yyDollar[2].expr}
 		}
 	case 47:
-		//line cc.y:484
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:492
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Twid, Left: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Twid, Left: yyDollar[2].expr}
 		}
 	case 48:
-		//line cc.y:489
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:497
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: PreInc, Left: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: PreInc, Left: yyDollar[2].expr}
 		}
 	case 49:
-		//line cc.y:494
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:502
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: PreDec, Left: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: PreDec, Left: yyDollar[2].expr}
 		}
 	case 50:
-		//line cc.y:499
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:507
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: SizeofExpr, Left: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: SizeofExpr, Left: yyDollar[2].expr}
 		}
 	case 51:
-		//line cc.y:504
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line cc.y:512
 		{
-			yyVAL.span = span(yyS[yypt-3].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: SizeofType, Type: yyS[yypt-1].typ}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[4].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: SizeofType, Type: yyDollar[3].typ}
 		}
 	case 52:
-		//line cc.y:509
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line cc.y:517
 		{
-			yyVAL.span = span(yyS[yypt-5].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Offsetof, Type: yyS[yypt-3].typ, Left: yyS[yypt-1].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[6].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Offsetof, Type: yyDollar[3].typ, Left: yyDollar[5].expr}
 		}
 	case 53:
-		//line cc.y:514
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line cc.y:522
 		{
-			yyVAL.span = span(yyS[yypt-3].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Cast, Type: yyS[yypt-2].typ, Left: yyS[yypt-0].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[4].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Cast, Type: yyDollar[2].typ, Left: yyDollar[4].expr}
 		}
 	case 54:
-		//line cc.y:519
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line cc.y:527
 		{
-			yyVAL.span = span(yyS[yypt-3].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: CastInit, Type: yyS[yypt-2].typ, Init: &Init{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Braced: yyS[yypt-0].inits, Id: nextId()}}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[4].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: CastInit, Type: yyDollar[2].typ, Init: &Init{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Braced: yyDollar[4].inits, Id: nextId()}}
 		}
 	case 55:
-		//line cc.y:524
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:532
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Paren, Left: yyS[yypt-1].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Paren, Left: yyDollar[2].expr}
 		}
 	case 56:
-		//line cc.y:529
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line cc.y:537
 		{
-			yyVAL.span = span(yyS[yypt-6].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: CUDACall, Left: yyS[yypt-6].expr, LaunchParams: yyS[yypt-4].exprs, List: yyS[yypt-1].exprs}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[7].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: CUDACall, Left: yyDollar[1].expr, LaunchParams: yyDollar[3].exprs, List: yyDollar[6].exprs}
 		}
 	case 57:
-		//line cc.y:534
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line cc.y:542
 		{
-			yyVAL.span = span(yyS[yypt-3].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Call, Left: yyS[yypt-3].expr, List: yyS[yypt-1].exprs}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[4].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Call, Left: yyDollar[1].expr, List: yyDollar[3].exprs}
 		}
 	case 58:
-		//line cc.y:539
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line cc.y:547
 		{
-			yyVAL.span = span(yyS[yypt-3].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Index, Left: yyS[yypt-3].expr, Right: yyS[yypt-1].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[4].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Index, Left: yyDollar[1].expr, Right: yyDollar[3].expr}
 		}
 	case 59:
-		//line cc.y:544
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:552
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: PostInc, Left: yyS[yypt-1].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: PostInc, Left: yyDollar[1].expr}
 		}
 	case 60:
-		//line cc.y:549
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:557
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: PostDec, Left: yyS[yypt-1].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: PostDec, Left: yyDollar[1].expr}
 		}
 	case 61:
-		//line cc.y:554
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line cc.y:562
 		{
-			yyVAL.span = span(yyS[yypt-5].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: VaArg, Left: yyS[yypt-3].expr, Type: yyS[yypt-1].typ}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[6].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: VaArg, Left: yyDollar[3].expr, Type: yyDollar[5].typ}
 		}
 	case 62:
-		//line cc.y:560
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line cc.y:567
 		{
-			yyVAL.span = Span{}
-			yyVAL.stmts = nil
+			yyVAL.span = span(yyDollar[1].span, yyDollar[4].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Builtin, List: yyDollar[3].exprs,
+				Text: &SymbolLiteral{Value: yyDollar[1].str, Id: nextId(), SyntaxInfo: SyntaxInfo{Span: yyDollar[1].span}},
+			}
 		}
 	case 63:
-		//line cc.y:565
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line cc.y:574
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.stmts = yyS[yypt-1].stmts
-			for _, d := range yyS[yypt-0].decls {
-				yyVAL.stmts = append(yyVAL.stmts, &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: StmtDecl, Decl: d})
+			yyVAL.span = span(yyDollar[1].span, yyDollar[4].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Builtin, TypeArgs: yyDollar[3].typs,
+				Text: &SymbolLiteral{Value: yyDollar[1].str, Id: nextId(), SyntaxInfo: SyntaxInfo{Span: yyDollar[1].span}},
 			}
 		}
 	case 64:
-		//line cc.y:573
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line cc.y:581
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.stmts = append(yyS[yypt-1].stmts, yyS[yypt-0].stmt)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[6].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Builtin, TypeArgs: yyDollar[3].typs, List: yyDollar[5].exprs,
+				Text: &SymbolLiteral{Value: yyDollar[1].str, Id: nextId(), SyntaxInfo: SyntaxInfo{Span: yyDollar[1].span}},
+			}
 		}
 	case 65:
-		//line cc.y:580
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line cc.y:588
 		{
-			yylex.(*lexer).pushScope()
+			yyVAL.span = span(yyDollar[1].span, yyDollar[7].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: CxxCast, Type: yyDollar[3].typ, Left: yyDollar[6].expr,
+				Text: &SymbolLiteral{Value: yyDollar[1].str, Id: nextId(), SyntaxInfo: SyntaxInfo{Span: yyDollar[1].span}},
+			}
 		}
 	case 66:
-		//line cc.y:584
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line cc.y:596
 		{
-			yyVAL.span = span(yyS[yypt-3].span, yyS[yypt-0].span)
-			yylex.(*lexer).popScope()
-			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Block, Block: yyS[yypt-1].stmts}
+			yyVAL.span = Span{}
+			yyVAL.stmts = nil
 		}
 	case 67:
-		//line cc.y:592
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:601
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.label = &Label{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Case, Expr: yyS[yypt-1].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.stmts = yyDollar[1].stmts
+			for _, d := range yyDollar[2].decls {
+				yyVAL.stmts = append(yyVAL.stmts, &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: StmtDecl, Decl: d})
+			}
 		}
 	case 68:
-		//line cc.y:597
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:609
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.label = &Label{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Default}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.stmts = append(yyDollar[1].stmts, yyDollar[2].stmt)
 		}
 	case 69:
-		//line cc.y:602
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:616
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.label = &Label{
-				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
-				Id:         nextId(),
-				Op:         LabelName,
-				Name: &SymbolLiteral{
-					Value:      yyS[yypt-1].str,
-					Id:         nextId(),
-					SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
-				},
-			}
+			yylex.(*lexer).pushScope()
 		}
 	case 70:
-		//line cc.y:618
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line cc.y:620
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.stmt = yyS[yypt-0].stmt
-			yyVAL.stmt.Labels = yyS[yypt-1].labels
+			yyVAL.span = span(yyDollar[1].span, yyDollar[4].span)
+			yylex.(*lexer).popScope()
+			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Block, Block: yyDollar[3].stmts}
 		}
 	case 71:
-		//line cc.y:626
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:628
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Empty}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.label = &Label{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Case, Expr: yyDollar[2].expr}
 		}
 	case 72:
-		//line cc.y:631
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:633
 		{
-			yyVAL.span = yyS[yypt-4].span
-			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Empty}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.label = &Label{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Default}
 		}
 	case 73:
-		//line cc.y:636
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:638
 		{
-			yyVAL.span = yyS[yypt-4].span
-			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Empty}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.label = &Label{
+				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
+				Id:         nextId(),
+				Op:         LabelName,
+				Name: &SymbolLiteral{
+					Value:      yyDollar[1].str,
+					Id:         nextId(),
+					SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
+				},
+			}
 		}
 	case 74:
-		//line cc.y:641
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:654
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.stmt = yyS[yypt-0].stmt
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.stmt = yyDollar[2].stmt
+			yyVAL.stmt.Labels = yyDollar[1].labels
 		}
 	case 75:
-		//line cc.y:646
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:662
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: StmtExpr, Expr: yyS[yypt-1].expr}
+			yyVAL.span = yyDollar[1].span
+			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Empty}
 		}
 	case 76:
-		//line cc.y:651
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line cc.y:667
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: ARGBEGIN, Block: yyS[yypt-1].stmts}
+			yyVAL.span = yyDollar[1].span
+			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Empty}
 		}
 	case 77:
-		//line cc.y:656
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line cc.y:672
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Break}
+			yyVAL.span = yyDollar[1].span
+			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Empty}
 		}
 	case 78:
-		//line cc.y:661
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:677
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Continue}
+			yyVAL.span = yyDollar[1].span
+			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 79:
-		//line cc.y:666
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:682
 		{
-			yyVAL.span = span(yyS[yypt-6].span, yyS[yypt-0].span)
-			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Do, Body: yyS[yypt-5].stmt, Expr: yyS[yypt-2].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: StmtExpr, Expr: yyDollar[1].expr}
 		}
 	case 80:
-		//line cc.y:671
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:687
 		{
-			yyVAL.span = span(yyS[yypt-8].span, yyS[yypt-0].span)
-			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
-				Id: nextId(), Op: For,
-				Pre:  yyS[yypt-6].expr,
-				Expr: yyS[yypt-4].expr,
-				Post: yyS[yypt-2].expr,
-				Body: yyS[yypt-0].stmt,
-			}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: ARGBEGIN, Block: yyDollar[2].stmts}
 		}
 	case 81:
-		//line cc.y:682
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:692
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Goto, Text: yyS[yypt-1].symlit}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Break}
 		}
 	case 82:
-		//line cc.y:687
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:697
 		{
-			yyVAL.span = span(yyS[yypt-4].span, yyS[yypt-0].span)
-			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: If, Expr: yyS[yypt-2].expr, Body: yyS[yypt-0].stmt}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Continue}
 		}
 	case 83:
-		//line cc.y:692
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line cc.y:702
 		{
-			yyVAL.span = span(yyS[yypt-6].span, yyS[yypt-0].span)
-			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: If, Expr: yyS[yypt-4].expr, Body: yyS[yypt-2].stmt, Else: yyS[yypt-0].stmt}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[7].span)
+			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Do, Body: yyDollar[2].stmt, Expr: yyDollar[5].expr}
 		}
 	case 84:
-		//line cc.y:697
+		yyDollar = yyS[yypt-9 : yypt+1]
+//line cc.y:707
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Return, Expr: yyS[yypt-1].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[9].span)
+			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
+				Id: nextId(), Op: For,
+				Pre:  yyDollar[3].expr,
+				Expr: yyDollar[5].expr,
+				Post: yyDollar[7].expr,
+				Body: yyDollar[9].stmt,
+			}
 		}
 	case 85:
-		//line cc.y:702
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:718
 		{
-			yyVAL.span = span(yyS[yypt-4].span, yyS[yypt-0].span)
-			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Switch, Expr: yyS[yypt-2].expr, Body: yyS[yypt-0].stmt}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Goto, Text: yyDollar[2].symlit}
 		}
 	case 86:
-		//line cc.y:707
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line cc.y:723
 		{
-			yyVAL.span = span(yyS[yypt-4].span, yyS[yypt-0].span)
-			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: While, Expr: yyS[yypt-2].expr, Body: yyS[yypt-0].stmt}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[5].span)
+			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: If, Expr: yyDollar[3].expr, Body: yyDollar[5].stmt}
 		}
 	case 87:
-		//line cc.y:714
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line cc.y:728
+		{
+			yyVAL.span = span(yyDollar[1].span, yyDollar[7].span)
+			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: If, Expr: yyDollar[3].expr, Body: yyDollar[5].stmt, Else: yyDollar[7].stmt}
+		}
+	case 88:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:733
+		{
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Return, Expr: yyDollar[2].expr}
+		}
+	case 89:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line cc.y:738
+		{
+			yyVAL.span = span(yyDollar[1].span, yyDollar[5].span)
+			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Switch, Expr: yyDollar[3].expr, Body: yyDollar[5].stmt}
+		}
+	case 90:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line cc.y:743
+		{
+			yyVAL.span = span(yyDollar[1].span, yyDollar[5].span)
+			yyVAL.stmt = &Stmt{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: While, Expr: yyDollar[3].expr, Body: yyDollar[5].stmt}
+		}
+	case 91:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line cc.y:750
 		{
 			yyVAL.span = Span{}
 			yyVAL.abdecor = func(t *Type) *Type { return t }
 		}
-	case 88:
-		//line cc.y:719
+	case 92:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:755
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			_, q, _ := splitTypeWords(yyS[yypt-1].syntaxs)
-			abdecor := yyS[yypt-0].abdecor
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			_, q, _ := splitTypeWords(yyDollar[2].syntaxs)
+			abdecor := yyDollar[3].abdecor
 			yyVAL.abdecor = func(t *Type) *Type {
 				return abdecor(&Type{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Kind: Ptr, Base: t, Qual: q, Id: nextId()})
 			}
 		}
-	case 89:
-		//line cc.y:728
+	case 93:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:764
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.abdecor = yyS[yypt-0].abdecor
+			yyVAL.span = yyDollar[1].span
+			yyVAL.abdecor = yyDollar[1].abdecor
 		}
-	case 90:
-		//line cc.y:735
+	case 94:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line cc.y:771
 		{
-			yyVAL.span = span(yyS[yypt-3].span, yyS[yypt-0].span)
-			abdecor := yyS[yypt-3].abdecor
-			decls := yyS[yypt-1].decls
+			yyVAL.span = span(yyDollar[1].span, yyDollar[4].span)
+			abdecor := yyDollar[1].abdecor
+			decls := yyDollar[3].decls
 			span := yyVAL.span
 			for _, decl := range decls {
 				t := decl.Type
@@ -1518,101 +1867,136 @@ yydefault:
 				return abdecor(&Type{SyntaxInfo: SyntaxInfo{Span: span}, Kind: Func, Base: t, Decls: decls, Id: nextId()})
 			}
 		}
-	case 91:
-		//line cc.y:759
+	case 95:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line cc.y:795
 		{
-			yyVAL.span = span(yyS[yypt-3].span, yyS[yypt-0].span)
-			abdecor := yyS[yypt-3].abdecor
+			yyVAL.span = span(yyDollar[1].span, yyDollar[4].span)
+			abdecor := yyDollar[1].abdecor
 			span := yyVAL.span
-			expr := yyS[yypt-1].expr
+			expr := yyDollar[3].expr
 			yyVAL.abdecor = func(t *Type) *Type {
 				return abdecor(&Type{SyntaxInfo: SyntaxInfo{Span: span}, Kind: Array, Base: t, Width: expr, Id: nextId()})
 			}
 
 		}
-	case 92:
-		//line cc.y:770
+	case 96:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line cc.y:806
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.abdecor = yyS[yypt-1].abdecor
+			yyVAL.span = span(yyDollar[1].span, yyDollar[5].span)
+			abdecor := yyDollar[1].abdecor
+			span := yyVAL.span
+			expr := yyDollar[4].expr
+			yyVAL.abdecor = func(t *Type) *Type {
+				return abdecor(&Type{SyntaxInfo: SyntaxInfo{Span: span}, Kind: Array, Base: t, Width: expr, Static: true, Id: nextId()})
+			}
 		}
-	case 93:
-		//line cc.y:778
+	case 97:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:816
 		{
-			yyVAL.span = yyS[yypt-0].span
-			name := yyS[yypt-0].symlit
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.abdecor = yyDollar[2].abdecor
+		}
+	case 98:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:824
+		{
+			yyVAL.span = yyDollar[1].span
+			name := yyDollar[1].symlit
 			yyVAL.decor = func(t *Type) (*Type, Syntax) { return t, name }
 		}
-	case 94:
-		//line cc.y:784
+	case 99:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:830
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			_, q, _ := splitTypeWords(yyS[yypt-1].syntaxs)
-			decor := yyS[yypt-0].decor
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			_, q, _ := splitTypeWords(yyDollar[2].syntaxs)
+			decor := yyDollar[3].decor
 			span := yyVAL.span
 			yyVAL.decor = func(t *Type) (*Type, Syntax) {
 				return decor(&Type{SyntaxInfo: SyntaxInfo{Span: span}, Kind: Ptr, Base: t, Qual: q, Id: nextId()})
 			}
 		}
-	case 95:
-		//line cc.y:794
+	case 100:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:840
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.decor = yyS[yypt-1].decor
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.decor = yyDollar[2].decor
 		}
-	case 96:
-		//line cc.y:799
+	case 101:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line cc.y:845
 		{
-			yyVAL.span = span(yyS[yypt-3].span, yyS[yypt-0].span)
-			decor := yyS[yypt-3].decor
-			decls := yyS[yypt-1].decls
+			yyVAL.span = span(yyDollar[1].span, yyDollar[4].span)
+			decor := yyDollar[1].decor
+			decls := yyDollar[3].decls
 			span := yyVAL.span
 			yyVAL.decor = func(t *Type) (*Type, Syntax) {
 				return decor(&Type{SyntaxInfo: SyntaxInfo{Span: span}, Kind: Func, Base: t, Decls: decls, Id: nextId()})
 			}
 		}
-	case 97:
-		//line cc.y:809
+	case 102:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line cc.y:855
 		{
-			yyVAL.span = span(yyS[yypt-3].span, yyS[yypt-0].span)
-			decor := yyS[yypt-3].decor
+			yyVAL.span = span(yyDollar[1].span, yyDollar[4].span)
+			decor := yyDollar[1].decor
 			span := yyVAL.span
-			expr := yyS[yypt-1].expr
+			expr := yyDollar[3].expr
 			yyVAL.decor = func(t *Type) (*Type, Syntax) {
 				return decor(&Type{SyntaxInfo: SyntaxInfo{Span: span}, Kind: Array, Base: t, Width: expr, Id: nextId()})
 			}
 		}
-	case 98:
-		//line cc.y:822
+	case 103:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line cc.y:865
+		{
+			yyVAL.span = span(yyDollar[1].span, yyDollar[5].span)
+			decor := yyDollar[1].decor
+			span := yyVAL.span
+			expr := yyDollar[4].expr
+			yyVAL.decor = func(t *Type) (*Type, Syntax) {
+				return decor(&Type{SyntaxInfo: SyntaxInfo{Span: span}, Kind: Array, Base: t, Width: expr, Static: true, Id: nextId()})
+			}
+		}
+	case 104:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:878
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.decl = &Decl{
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 				Name: &SymbolLiteral{
-					Value:      yyS[yypt-0].str,
+					Value:      yyDollar[1].str,
 					Id:         nextId(),
 					SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 				},
 				Id: nextId(),
 			}
 		}
-	case 99:
-		//line cc.y:835
+	case 105:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:891
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.decl = &Decl{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Type: yyS[yypt-0].abdecor(yyS[yypt-1].typ), Id: nextId()}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.decl = &Decl{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Type: yyDollar[2].abdecor(yyDollar[1].typ), Id: nextId()}
 		}
-	case 100:
-		//line cc.y:840
+	case 106:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:896
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			typ, name := yyS[yypt-0].decor(yyS[yypt-1].typ)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			typ, name := yyDollar[2].decor(yyDollar[1].typ)
 			yyVAL.decl = &Decl{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Name: name, Type: typ, Id: nextId()}
 		}
-	case 101:
-		//line cc.y:846
+	case 107:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:902
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.decl = &Decl{
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 				Name: &LanguageKeyword{
@@ -1623,272 +2007,323 @@ yydefault:
 				Id: nextId(),
 			}
 		}
-	case 102:
-		//line cc.y:862
+	case 108:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:918
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.idec = idecor{yyS[yypt-0].decor, nil}
+			yyVAL.span = yyDollar[1].span
+			yyVAL.idec = idecor{yyDollar[1].decor, nil}
 		}
-	case 103:
-		//line cc.y:867
+	case 109:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:923
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.idec = idecor{yyS[yypt-2].decor, yyS[yypt-0].init}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.idec = idecor{yyDollar[1].decor, yyDollar[3].init}
 		}
-	case 104:
-		//line cc.y:875
+	case 110:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:931
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.syntax = &LanguageKeyword{
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 			}
 		}
-	case 105:
-		//line cc.y:884
+	case 111:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:940
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.syntax = &LanguageKeyword{
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 			}
 		}
-	case 106:
-		//line cc.y:893
+	case 112:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:949
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.syntax = &LanguageKeyword{
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 			}
 		}
-	case 107:
-		//line cc.y:902
+	case 113:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:958
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.syntax = &LanguageKeyword{
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 			}
 		}
-	case 108:
-		//line cc.y:911
+	case 114:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:967
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.syntax = &LanguageKeyword{
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 			}
 		}
-	case 109:
-		//line cc.y:920
+	case 115:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:976
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.syntax = &LanguageKeyword{
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 			}
 		}
-	case 110:
-		//line cc.y:932
+	case 116:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:985
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.syntax = &LanguageKeyword{
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 			}
 		}
-	case 111:
-		//line cc.y:941
+	case 117:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:997
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.syntax = &LanguageKeyword{
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 			}
 		}
-	case 112:
-		//line cc.y:950
+	case 118:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1006
+		{
+			yyVAL.span = yyDollar[1].span
+			yyVAL.syntax = &LanguageKeyword{
+				Value:      yyDollar[1].str,
+				Id:         nextId(),
+				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
+			}
+		}
+	case 119:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1015
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
+			yyVAL.syntax = &LanguageKeyword{
+				Value:      yyDollar[1].str,
+				Id:         nextId(),
+				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
+			}
+		}
+	case 120:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1024
+		{
+			yyVAL.span = yyDollar[1].span
 			yyVAL.syntax = &SymbolLiteral{
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 			}
 		}
-	case 113:
-		//line cc.y:959
+	case 121:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1033
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.syntax = &SymbolLiteral{
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 			}
 		}
-	case 114:
-		//line cc.y:968
+	case 122:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1042
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.syntax = &SymbolLiteral{
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 			}
 		}
-	case 115:
-		//line cc.y:977
+	case 123:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1051
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.syntax = &SymbolLiteral{
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 			}
 		}
-	case 116:
-		//line cc.y:986
+	case 124:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1060
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.syntax = &LanguageKeyword{
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 			}
 		}
-	case 117:
-		//line cc.y:998
+	case 125:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1072
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.syntax = &LanguageKeyword{
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 			}
 		}
-	case 118:
-		//line cc.y:1007
+	case 126:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1081
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.syntax = &SymbolLiteral{
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 			}
 		}
-	case 119:
-		//line cc.y:1016
+	case 127:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1090
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.syntax = &SymbolLiteral{
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 			}
 		}
-	case 120:
-		//line cc.y:1025
+	case 128:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1099
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.syntax = &SymbolLiteral{
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 			}
 		}
-	case 121:
-		//line cc.y:1034
+	case 129:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1108
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.syntax = &SymbolLiteral{
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 			}
 		}
-	case 122:
-		//line cc.y:1043
+	case 130:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1117
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.syntax = &SymbolLiteral{
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 			}
 		}
-	case 123:
-		//line cc.y:1052
+	case 131:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1126
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.syntax = &SymbolLiteral{
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 			}
 		}
-	case 124:
-		//line cc.y:1061
+	case 132:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1135
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.syntax = &SymbolLiteral{
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 			}
 		}
-	case 125:
-		//line cc.y:1070
+	case 133:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1144
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.syntax = &SymbolLiteral{
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 			}
 		}
-	case 126:
-		//line cc.y:1081
+	case 134:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1155
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.syntax = yyS[yypt-0].syntax
+			yyVAL.span = yyDollar[1].span
+			yyVAL.syntax = yyDollar[1].syntax
 		}
-	case 127:
-		//line cc.y:1086
+	case 135:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1160
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.syntax = yyS[yypt-0].syntax
+			yyVAL.span = yyDollar[1].span
+			yyVAL.syntax = yyDollar[1].syntax
 		}
-	case 128:
-		//line cc.y:1093
+	case 136:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1167
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.syntax = yyS[yypt-0].syntax
+			yyVAL.span = yyDollar[1].span
+			yyVAL.syntax = yyDollar[1].syntax
 		}
-	case 129:
-		//line cc.y:1098
+	case 137:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1172
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.syntax = yyS[yypt-0].syntax
+			yyVAL.span = yyDollar[1].span
+			yyVAL.syntax = yyDollar[1].syntax
 		}
-	case 130:
-		//line cc.y:1106
+	case 138:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1180
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.typ = yyS[yypt-0].typ
+			yyVAL.span = yyDollar[1].span
+			yyVAL.typ = yyDollar[1].typ
 			if yyVAL.typ == nil {
 				yyVAL.typ = &Type{
 					Kind: TypedefType,
 					Name: &SymbolLiteral{
-						Value:      yyS[yypt-0].str,
+						Value:      yyDollar[1].str,
 						Id:         nextId(),
 						SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 					},
@@ -1896,115 +2331,129 @@ yydefault:
 				}
 			}
 		}
-	case 131:
-		//line cc.y:1130
+	case 139:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line cc.y:1196
+		{
+			yyVAL.span = span(yyDollar[1].span, yyDollar[4].span)
+			yyVAL.typ = qualify(yyDollar[3].typ, Atomic)
+		}
+	case 140:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1209
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.tc.c, yyVAL.tc.q, yyVAL.tc.t = splitTypeWords(
-				append(yyS[yypt-0].syntaxs, &SymbolLiteral{
+				append(yyDollar[1].syntaxs, &SymbolLiteral{
 					Value:      "int",
 					Id:         nextId(),
 					SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 				}))
 		}
-	case 132:
-		//line cc.y:1140
+	case 141:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:1219
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.tc.c, yyVAL.tc.q, _ = splitTypeWords(append(yyS[yypt-2].syntaxs, yyS[yypt-0].syntaxs...))
-			yyVAL.tc.t = yyS[yypt-1].typ
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.tc.c, yyVAL.tc.q, _ = splitTypeWords(append(yyDollar[1].syntaxs, yyDollar[3].syntaxs...))
+			yyVAL.tc.t = yyDollar[2].typ
 		}
-	case 133:
-		//line cc.y:1146
+	case 142:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:1225
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyS[yypt-2].syntaxs = append(yyS[yypt-2].syntaxs, yyS[yypt-1].syntax)
-			yyS[yypt-2].syntaxs = append(yyS[yypt-2].syntaxs, yyS[yypt-0].syntaxs...)
-			yyVAL.tc.c, yyVAL.tc.q, yyVAL.tc.t = splitTypeWords(yyS[yypt-2].syntaxs)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyDollar[1].syntaxs = append(yyDollar[1].syntaxs, yyDollar[2].syntax)
+			yyDollar[1].syntaxs = append(yyDollar[1].syntaxs, yyDollar[3].syntaxs...)
+			yyVAL.tc.c, yyVAL.tc.q, yyVAL.tc.t = splitTypeWords(yyDollar[1].syntaxs)
 		}
-	case 134:
-		//line cc.y:1153
+	case 143:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:1232
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.tc.c, yyVAL.tc.q, _ = splitTypeWords(yyS[yypt-0].syntaxs)
-			yyVAL.tc.t = yyS[yypt-1].typ
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.tc.c, yyVAL.tc.q, _ = splitTypeWords(yyDollar[2].syntaxs)
+			yyVAL.tc.t = yyDollar[1].typ
 		}
-	case 135:
-		//line cc.y:1159
+	case 144:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:1238
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
 			var ts []Syntax
-			ts = append(ts, yyS[yypt-1].syntax)
-			ts = append(ts, yyS[yypt-0].syntaxs...)
+			ts = append(ts, yyDollar[1].syntax)
+			ts = append(ts, yyDollar[2].syntaxs...)
 			//PrintStack()
 			yyVAL.tc.c, yyVAL.tc.q, yyVAL.tc.t = splitTypeWords(ts)
 		}
-	case 136:
-		//line cc.y:1171
+	case 145:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1250
 		{
-			yyVAL.span = yyS[yypt-0].span
-			if yyS[yypt-0].tc.c != 0 {
-				yylex.(*lexer).Errorf("%v not allowed here", yyS[yypt-0].tc.c)
+			yyVAL.span = yyDollar[1].span
+			if yyDollar[1].tc.c != 0 {
+				yylex.(*lexer).Errorf("%v not allowed here", yyDollar[1].tc.c)
 			}
-			if yyS[yypt-0].tc.q != 0 && yyS[yypt-0].tc.q != Const && yyS[yypt-0].tc.q != Volatile {
-				yylex.(*lexer).Errorf("%v ignored here (TODO)?", yyS[yypt-0].tc.q)
+			if yyDollar[1].tc.q&^(Const|Volatile|Atomic) != 0 {
+				yylex.(*lexer).Errorf("%v ignored here (TODO)?", yyDollar[1].tc.q)
 			}
-			yyVAL.typ = yyS[yypt-0].tc.t
+			yyVAL.typ = qualify(yyDollar[1].tc.t, yyDollar[1].tc.q)
 		}
-	case 137:
-		//line cc.y:1184
+	case 146:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:1263
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.typ = yyS[yypt-0].abdecor(yyS[yypt-1].typ)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.typ = yyDollar[2].abdecor(yyDollar[1].typ)
 		}
-	case 138:
-		//line cc.y:1192
+	case 147:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:1271
 		{
 			lx := yylex.(*lexer)
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			// TODO: use $1.q
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
 			yyVAL.decls = nil
-			for _, idec := range yyS[yypt-1].idecs {
-				typ, name := idec.d(yyS[yypt-2].tc.t)
+			for _, idec := range yyDollar[2].idecs {
+				typ, name := idec.d(qualify(yyDollar[1].tc.t, yyDollar[1].tc.q))
 				d := &Decl{
 					SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 					Name:       name,
 					Type:       typ,
-					Storage:    yyS[yypt-2].tc.c,
+					Storage:    yyDollar[1].tc.c,
 					Init:       idec.i,
 					Id:         nextId(),
 				}
 				lx.pushDecl(d)
 				yyVAL.decls = append(yyVAL.decls, d)
 			}
-			if yyS[yypt-1].idecs == nil {
+			if yyDollar[2].idecs == nil {
 				d := &Decl{
 					SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 					Name:       &SymbolLiteral{},
-					Type:       yyS[yypt-2].tc.t,
-					Storage:    yyS[yypt-2].tc.c,
+					Type:       qualify(yyDollar[1].tc.t, yyDollar[1].tc.q),
+					Storage:    yyDollar[1].tc.c,
 					Id:         nextId(),
 				}
 				lx.pushDecl(d)
 				yyVAL.decls = append(yyVAL.decls, d)
 			}
 		}
-	case 139:
-		//line cc.y:1225
+	case 148:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:1303
 		{
 			lx := yylex.(*lexer)
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			// TODO: use $1.q
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
 			yyVAL.decls = nil
-			for _, idec := range yyS[yypt-1].idecs {
-				typ, name := idec.d(yyS[yypt-2].tc.t)
+			for _, idec := range yyDollar[2].idecs {
+				typ, name := idec.d(qualify(yyDollar[1].tc.t, yyDollar[1].tc.q))
 				d := lx.lookupDecl(name)
 				if d == nil {
 					d = &Decl{
 						SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 						Name:       name,
 						Type:       typ,
-						Storage:    yyS[yypt-2].tc.c,
+						Storage:    yyDollar[1].tc.c,
 						Init:       idec.i,
 						Id:         nextId(),
 					}
@@ -2017,47 +2466,51 @@ yydefault:
 				}
 				yyVAL.decls = append(yyVAL.decls, d)
 			}
-			if yyS[yypt-1].idecs == nil {
+			if yyDollar[2].idecs == nil {
 				d := &Decl{
 					SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 					Name:       &SymbolLiteral{},
-					Type:       yyS[yypt-2].tc.t,
-					Storage:    yyS[yypt-2].tc.c,
+					Type:       qualify(yyDollar[1].tc.t, yyDollar[1].tc.q),
+					Storage:    yyDollar[1].tc.c,
 					Id:         nextId(),
 				}
 				lx.pushDecl(d)
 				yyVAL.decls = append(yyVAL.decls, d)
 			}
 		}
-	case 140:
-		//line cc.y:1266
+	case 149:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1343
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.decls = yyS[yypt-0].decls
+			yyVAL.span = yyDollar[1].span
+			yyVAL.decls = yyDollar[1].decls
 		}
-	case 141:
-		//line cc.y:1271
+	case 150:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1348
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.decls = []*Decl{yyS[yypt-0].decl}
+			yyVAL.span = yyDollar[1].span
+			yyVAL.decls = []*Decl{yyDollar[1].decl}
 		}
-	case 142:
-		//line cc.y:1276
+	case 151:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line cc.y:1353
 		{
-			yyVAL.decls = yyS[yypt-1].decls
+			yyVAL.decls = yyDollar[4].decls
 		}
-	case 143:
-		//line cc.y:1282
+	case 152:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:1359
 		{
 			lx := yylex.(*lexer)
-			typ, name := yyS[yypt-1].decor(yyS[yypt-2].tc.t)
+			typ, name := yyDollar[2].decor(qualify(yyDollar[1].tc.t, yyDollar[1].tc.q))
 			if typ.Kind != Func {
 				yylex.(*lexer).Errorf("invalid function definition")
 				return 0
 			}
 			d := lx.lookupDecl(name)
 			if d == nil {
-				d = &Decl{Name: name, Type: typ, Storage: yyS[yypt-2].tc.c, Id: nextId()}
+				d = &Decl{Name: name, Type: typ, Storage: yyDollar[1].tc.c, Id: nextId()}
 				lx.pushDecl(d)
 			} else {
 				d.Type = typ
@@ -2068,74 +2521,94 @@ yydefault:
 				lx.pushDecl(decl)
 			}
 		}
-	case 144:
-		//line cc.y:1303
+	case 153:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line cc.y:1380
 		{
 			yylex.(*lexer).popScope()
-			yyVAL.span = span(yyS[yypt-4].span, yyS[yypt-0].span)
-			yyVAL.decl = yyS[yypt-1].decl
+			yyVAL.span = span(yyDollar[1].span, yyDollar[5].span)
+			yyVAL.decl = yyDollar[4].decl
 			yyVAL.decl.Span = yyVAL.span
-			if yyS[yypt-2].decls != nil {
+			if yyDollar[3].decls != nil {
 				yylex.(*lexer).Errorf("cannot use pre-prototype definitions")
 			}
-			yyVAL.decl.Body = yyS[yypt-0].stmt
+			yyVAL.decl.Body = yyDollar[5].stmt
 		}
-	case 145:
-		//line cc.y:1316
+	case 154:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1393
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.symlit = &SymbolLiteral{
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 			}
 		}
-	case 146:
-		//line cc.y:1325
+	case 155:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1402
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.symlit = &SymbolLiteral{
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
-				Value:      yyS[yypt-0].str,
+				Value:      yyDollar[1].str,
 				Id:         nextId(),
 			}
 		}
-	case 147:
-		//line cc.y:1337
+	case 156:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1414
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.tk = Struct
 		}
-	case 148:
-		//line cc.y:1342
+	case 157:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1419
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.tk = Union
 		}
-	case 149:
-		//line cc.y:1349
+	case 158:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1426
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.decor = yyS[yypt-0].decor
+			yyVAL.span = yyDollar[1].span
+			yyVAL.decor = yyDollar[1].decor
 		}
-	case 150:
-		//line cc.y:1354
+	case 159:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:1431
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			name := yyS[yypt-2].syntax
-			expr := yyS[yypt-0].expr
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			name := yyDollar[1].syntax
+			expr := yyDollar[3].expr
 			yyVAL.decor = func(t *Type) (*Type, Syntax) {
 				t.Width = expr
 				return t, name
 			}
 		}
-	case 151:
-		//line cc.y:1366
+	case 160:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:1443
+		{
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			switch yyDollar[1].str {
+			case "public", "protected", "private":
+			default:
+				yylex.(*lexer).Errorf("unexpected %s in struct", yyDollar[1].str)
+			}
+			yyVAL.decls = nil
+		}
+	case 161:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:1453
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
 			yyVAL.decls = nil
-			for _, decor := range yyS[yypt-1].decors {
-				typ, name := decor(yyS[yypt-2].typ)
+			for _, decor := range yyDollar[2].decors {
+				typ, name := decor(yyDollar[1].typ)
 				yyVAL.decls = append(yyVAL.decls, &Decl{
 					SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 					Name:       name,
@@ -2143,80 +2616,222 @@ yydefault:
 					Id:         nextId(),
 				})
 			}
-			if yyS[yypt-1].decors == nil {
+			if yyDollar[2].decors == nil {
 				yyVAL.decls = append(yyVAL.decls, &Decl{
 					SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
-					Type:       yyS[yypt-2].typ,
+					Type:       yyDollar[1].typ,
 					Id:         nextId(),
 				})
 			}
 		}
-	case 152:
-		//line cc.y:1389
+	case 162:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:1476
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
 			yyVAL.typ = yylex.(*lexer).pushType(&Type{
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
-				Kind:       yyS[yypt-1].tk,
-				Tag:        yyS[yypt-0].symlit,
+				Kind:       yyDollar[1].tk,
+				Tag:        yyDollar[2].symlit,
 				Id:         nextId(),
 			})
 		}
-	case 153:
-		//line cc.y:1399
+	case 163:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line cc.y:1486
 		{
-			yyVAL.span = span(yyS[yypt-4].span, yyS[yypt-0].span)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[5].span)
 			yyVAL.typ = yylex.(*lexer).pushType(&Type{
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
-				Kind:       yyS[yypt-4].tk,
-				Tag:        yyS[yypt-3].syntax,
-				Decls:      yyS[yypt-1].decls,
+				Kind:       yyDollar[1].tk,
+				Tag:        yyDollar[2].syntax,
+				Decls:      yyDollar[4].decls,
 				Id:         nextId(),
 			})
 		}
-	case 154:
-		//line cc.y:1412
+	case 164:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line cc.y:1497
+		{
+			yyVAL.span = span(yyDollar[1].span, yyDollar[7].span)
+			yyVAL.typ = yylex.(*lexer).pushClass(&Type{
+				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
+				Kind:       yyDollar[1].tk,
+				Tag:        yyDollar[2].symlit,
+				Decls:      yyDollar[6].decls,
+				Bases:      yyDollar[4].typs,
+				Id:         nextId(),
+			})
+		}
+	case 165:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:1509
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.prefix = &Prefix{Span: yyVAL.span, Dot: yyS[yypt-0].symlit}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.typ = yylex.(*lexer).pushClass(&Type{
+				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
+				Kind:       Struct,
+				Tag:        yyDollar[2].symlit,
+				Id:         nextId(),
+			})
 		}
-	case 155:
-		//line cc.y:1419
+	case 166:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line cc.y:1519
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Arrow, Left: yyS[yypt-2].expr, Text: yyS[yypt-0].symlit}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[6].span)
+			yyVAL.typ = yylex.(*lexer).pushClass(&Type{
+				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
+				Kind:       Struct,
+				Tag:        yyDollar[2].symlit,
+				Decls:      yyDollar[5].decls,
+				Bases:      yyDollar[3].typs,
+				Id:         nextId(),
+			})
 		}
-	case 156:
-		//line cc.y:1424
+	case 167:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line cc.y:1533
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Dot, Left: yyS[yypt-2].expr, Text: yyS[yypt-0].symlit}
+			yyVAL.typs = nil
 		}
-	case 157:
-		//line cc.y:1432
+	case 168:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:1537
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.typ = yylex.(*lexer).pushType(&Type{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Kind: Enum, Tag: yyS[yypt-0].symlit, Id: nextId()})
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.typs = yyDollar[2].typs
 		}
-	case 158:
-		//line cc.y:1437
+	case 169:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1544
 		{
-			yyVAL.span = span(yyS[yypt-5].span, yyS[yypt-0].span)
-			yyVAL.typ = yylex.(*lexer).pushType(&Type{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Kind: Enum, Tag: yyS[yypt-4].syntax, Decls: yyS[yypt-2].decls, Id: nextId()})
+			yyVAL.span = yyDollar[1].span
+			yyVAL.typs = []*Type{yyDollar[1].typ}
 		}
-	case 159:
-		//line cc.y:1444
+	case 170:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:1549
+		{
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.typs = append(yyDollar[1].typs, yyDollar[3].typ)
+		}
+	case 171:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1556
+		{
+			yyVAL.span = yyDollar[1].span
+			yyVAL.typ = yylex.(*lexer).lookupBase(yyDollar[1].symlit)
+		}
+	case 172:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:1561
+		{
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			switch yyDollar[1].str {
+			case "public", "protected", "private", "virtual":
+			default:
+				yylex.(*lexer).Errorf("unexpected %s in base class list", yyDollar[1].str)
+			}
+			yyVAL.typ = yylex.(*lexer).lookupBase(yyDollar[2].symlit)
+		}
+	case 173:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:1573
+		{
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.prefix = &Prefix{Span: yyVAL.span, Dot: yyDollar[2].symlit}
+		}
+	case 174:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:1580
+		{
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Arrow, Left: yyDollar[1].expr, Text: yyDollar[3].symlit}
+		}
+	case 175:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:1585
+		{
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.expr = &Expr{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Id: nextId(), Op: Dot, Left: yyDollar[1].expr, Text: yyDollar[3].symlit}
+		}
+	case 176:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:1593
+		{
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.typ = yylex.(*lexer).pushType(&Type{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Kind: Enum, Tag: yyDollar[2].symlit, Id: nextId()})
+		}
+	case 177:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line cc.y:1598
+		{
+			yyVAL.span = span(yyDollar[1].span, yyDollar[6].span)
+			yyVAL.typ = yylex.(*lexer).pushType(&Type{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Kind: Enum, Tag: yyDollar[2].syntax, Decls: yyDollar[4].decls, Id: nextId()})
+		}
+	case 178:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line cc.y:1603
+		{
+			yyVAL.span = span(yyDollar[1].span, yyDollar[7].span)
+			yyVAL.typ = yylex.(*lexer).pushType(&Type{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Kind: Enum, Tag: yyDollar[2].symlit, Base: yyDollar[3].typ, Decls: yyDollar[5].decls, Id: nextId()})
+		}
+	case 179:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:1608
+		{
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.typ = yylex.(*lexer).pushType(&Type{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Kind: Enum, Tag: yyDollar[3].symlit, Id: nextId()})
+		}
+	case 180:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line cc.y:1613
+		{
+			yyVAL.span = span(yyDollar[1].span, yyDollar[8].span)
+			yyVAL.typ = yylex.(*lexer).pushType(&Type{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Kind: Enum, Tag: yyDollar[3].symlit, Base: yyDollar[4].typ, Decls: yyDollar[6].decls, Id: nextId()})
+		}
+	case 181:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line cc.y:1620
+		{
+			yyVAL.typ = nil
+		}
+	case 182:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1624
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
+			yyVAL.span = yyDollar[1].span
+			yyVAL.typ = yyDollar[1].typ
+		}
+	case 183:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:1631
+		{
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.typ = yyDollar[2].typ
+		}
+	case 184:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:1636
+		{
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			_, _, yyVAL.typ = splitTypeWords(yyDollar[2].syntaxs)
+		}
+	case 185:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:1643
+		{
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
 			var x *Init
-			if yyS[yypt-0].expr != nil {
-				x = &Init{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Expr: yyS[yypt-0].expr, Id: nextId()}
+			if yyDollar[2].expr != nil {
+				x = &Init{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Expr: yyDollar[2].expr, Id: nextId()}
 			}
 			yyVAL.decl = &Decl{
 				SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
 				Name: &SymbolLiteral{
 					SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
-					Value:      yyS[yypt-1].str,
+					Value:      yyDollar[1].str,
 					Id:         nextId(),
 				},
 				Init: x,
@@ -2224,389 +2839,466 @@ yydefault:
 			}
 			yylex.(*lexer).pushDecl(yyVAL.decl)
 		}
-	case 160:
-		//line cc.y:1465
+	case 186:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:1664
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.expr = yyS[yypt-0].expr
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.expr = yyDollar[2].expr
 		}
-	case 161:
-		//line cc.y:1473
+	case 187:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1672
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.init = &Init{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Expr: yyS[yypt-0].expr, Id: nextId()}
+			yyVAL.span = yyDollar[1].span
+			yyVAL.init = &Init{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Expr: yyDollar[1].expr, Id: nextId()}
 		}
-	case 162:
-		//line cc.y:1478
+	case 188:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1677
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.init = &Init{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Braced: yyS[yypt-0].inits, Id: nextId()}
+			yyVAL.span = yyDollar[1].span
+			yyVAL.init = &Init{SyntaxInfo: SyntaxInfo{Span: yyVAL.span}, Braced: yyDollar[1].inits, Id: nextId()}
 		}
-	case 163:
-		//line cc.y:1485
+	case 189:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:1684
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
 			yyVAL.inits = []*Init{}
 		}
-	case 164:
-		//line cc.y:1490
+	case 190:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line cc.y:1689
 		{
-			yyVAL.span = span(yyS[yypt-3].span, yyS[yypt-0].span)
-			yyVAL.inits = append(yyS[yypt-2].inits, yyS[yypt-1].init)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[4].span)
+			yyVAL.inits = append(yyDollar[2].inits, yyDollar[3].init)
 		}
-	case 165:
-		//line cc.y:1495
+	case 191:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line cc.y:1694
 		{
-			yyVAL.span = span(yyS[yypt-4].span, yyS[yypt-0].span)
-			yyVAL.inits = append(yyS[yypt-3].inits, yyS[yypt-2].init)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[5].span)
+			yyVAL.inits = append(yyDollar[2].inits, yyDollar[3].init)
 		}
-	case 166:
-		//line cc.y:1501
+	case 192:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line cc.y:1700
 		{
 			yyVAL.span = Span{}
 			yyVAL.inits = nil
 		}
-	case 167:
-		//line cc.y:1506
+	case 193:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:1705
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.inits = append(yyS[yypt-2].inits, yyS[yypt-1].init)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.inits = append(yyDollar[1].inits, yyDollar[2].init)
 		}
-	case 168:
-		//line cc.y:1513
+	case 194:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1712
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.init = yyS[yypt-0].init
+			yyVAL.span = yyDollar[1].span
+			yyVAL.init = yyDollar[1].init
 		}
-	case 169:
-		//line cc.y:1518
+	case 195:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:1717
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.init = yyS[yypt-0].init
-			yyVAL.init.Prefix = yyS[yypt-2].prefixes
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.init = yyDollar[3].init
+			yyVAL.init.Prefix = yyDollar[1].prefixes
 		}
-	case 170:
-		//line cc.y:1526
+	case 196:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:1725
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.prefix = &Prefix{Span: yyVAL.span, Index: yyS[yypt-1].expr}
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.prefix = &Prefix{Span: yyVAL.span, Index: yyDollar[2].expr}
 		}
-	case 171:
-		//line cc.y:1532
+	case 197:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line cc.y:1731
 		{
 			yyVAL.span = Span{}
 		}
-	case 172:
-		//line cc.y:1536
+	case 198:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1735
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 		}
-	case 173:
-		//line cc.y:1541
+	case 199:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line cc.y:1740
 		{
 			yyVAL.span = Span{}
 		}
-	case 174:
-		//line cc.y:1545
+	case 200:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1744
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 		}
-	case 175:
-		//line cc.y:1554
+	case 201:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1753
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.prefixes = []*Prefix{yyS[yypt-0].prefix}
+			yyVAL.span = yyDollar[1].span
+			yyVAL.prefixes = []*Prefix{yyDollar[1].prefix}
 		}
-	case 176:
-		//line cc.y:1559
+	case 202:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:1758
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.prefixes = append(yyS[yypt-1].prefixes, yyS[yypt-0].prefix)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.prefixes = append(yyDollar[1].prefixes, yyDollar[2].prefix)
 		}
-	case 177:
-		//line cc.y:1565
+	case 203:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line cc.y:1764
 		{
 			yyVAL.span = Span{}
 			yyVAL.syntax = &EmptyLiteral{}
 		}
-	case 178:
-		//line cc.y:1570
+	case 204:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1769
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.syntax = yyS[yypt-0].symlit
+			yyVAL.span = yyDollar[1].span
+			yyVAL.syntax = yyDollar[1].symlit
 		}
-	case 179:
-		//line cc.y:1576
+	case 205:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line cc.y:1775
 		{
 			yyVAL.span = Span{}
 			yyVAL.expr = nil
 		}
-	case 180:
-		//line cc.y:1581
+	case 206:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1780
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.expr = yyS[yypt-0].expr
+			yyVAL.span = yyDollar[1].span
+			yyVAL.expr = yyDollar[1].expr
 		}
-	case 181:
-		//line cc.y:1587
+	case 207:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line cc.y:1786
 		{
 			yyVAL.span = Span{}
 			yyVAL.expr = nil
 		}
-	case 182:
-		//line cc.y:1592
+	case 208:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1791
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.expr = yyS[yypt-0].expr
+			yyVAL.span = yyDollar[1].span
+			yyVAL.expr = yyDollar[1].expr
 		}
-	case 183:
-		//line cc.y:1599
+	case 209:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1798
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.exprs = []*Expr{yyS[yypt-0].expr}
+			yyVAL.span = yyDollar[1].span
+			yyVAL.exprs = []*Expr{yyDollar[1].expr}
 		}
-	case 184:
-		//line cc.y:1604
+	case 210:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:1803
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.exprs = append(yyS[yypt-2].exprs, yyS[yypt-0].expr)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.exprs = append(yyDollar[1].exprs, yyDollar[3].expr)
 		}
-	case 185:
-		//line cc.y:1610
+	case 211:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1810
+		{
+			yyVAL.span = yyDollar[1].span
+			yyVAL.typs = []*Type{yyDollar[1].typ}
+		}
+	case 212:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:1815
+		{
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.typs = append(yyDollar[1].typs, yyDollar[3].typ)
+		}
+	case 213:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line cc.y:1821
 		{
 			yyVAL.span = Span{}
 			yyVAL.exprs = nil
 		}
-	case 186:
-		//line cc.y:1615
+	case 214:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1826
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.exprs = yyS[yypt-0].exprs
+			yyVAL.span = yyDollar[1].span
+			yyVAL.exprs = yyDollar[1].exprs
 		}
-	case 187:
-		//line cc.y:1621
+	case 215:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line cc.y:1832
 		{
 			yyVAL.span = Span{}
 			yyVAL.decls = nil
 		}
-	case 188:
-		//line cc.y:1626
+	case 216:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:1837
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.decls = append(yyS[yypt-1].decls, yyS[yypt-0].decls...)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.decls = append(yyDollar[1].decls, yyDollar[2].decls...)
 		}
-	case 189:
-		//line cc.y:1632
+	case 217:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line cc.y:1843
 		{
 			yyVAL.span = Span{}
 			yyVAL.labels = nil
 		}
-	case 190:
-		//line cc.y:1637
+	case 218:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:1848
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.labels = append(yyS[yypt-1].labels, yyS[yypt-0].label)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.labels = append(yyDollar[1].labels, yyDollar[2].label)
 		}
-	case 191:
-		//line cc.y:1644
+	case 219:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1855
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.decls = []*Decl{yyS[yypt-0].decl}
+			yyVAL.span = yyDollar[1].span
+			yyVAL.decls = []*Decl{yyDollar[1].decl}
 		}
-	case 192:
-		//line cc.y:1649
+	case 220:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:1860
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.decls = append(yyS[yypt-2].decls, yyS[yypt-0].decl)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.decls = append(yyDollar[1].decls, yyDollar[3].decl)
 		}
-	case 193:
-		//line cc.y:1655
+	case 221:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line cc.y:1866
 		{
 			yyVAL.span = Span{}
 			yyVAL.decls = nil
 		}
-	case 194:
-		//line cc.y:1660
+	case 222:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1871
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.decls = yyS[yypt-0].decls
+			yyVAL.span = yyDollar[1].span
+			yyVAL.decls = yyDollar[1].decls
 		}
-	case 195:
-		//line cc.y:1667
+	case 223:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1878
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.idecs = []idecor{yyS[yypt-0].idec}
+			yyVAL.span = yyDollar[1].span
+			yyVAL.idecs = []idecor{yyDollar[1].idec}
 		}
-	case 196:
-		//line cc.y:1672
+	case 224:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:1883
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.idecs = append(yyS[yypt-2].idecs, yyS[yypt-0].idec)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.idecs = append(yyDollar[1].idecs, yyDollar[3].idec)
 		}
-	case 197:
-		//line cc.y:1678
+	case 225:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line cc.y:1889
 		{
 			yyVAL.span = Span{}
 			yyVAL.idecs = nil
 		}
-	case 198:
-		//line cc.y:1683
+	case 226:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1894
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.idecs = yyS[yypt-0].idecs
+			yyVAL.span = yyDollar[1].span
+			yyVAL.idecs = yyDollar[1].idecs
 		}
-	case 199:
-		//line cc.y:1690
+	case 227:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1901
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.syntaxs = []Syntax{yyS[yypt-0].syntax}
+			yyVAL.span = yyDollar[1].span
+			yyVAL.syntaxs = []Syntax{yyDollar[1].syntax}
 		}
-	case 200:
-		//line cc.y:1695
+	case 228:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:1906
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.syntaxs = append(yyS[yypt-1].syntaxs, yyS[yypt-0].syntax)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.syntaxs = append(yyDollar[1].syntaxs, yyDollar[2].syntax)
 		}
-	case 201:
-		//line cc.y:1701
+	case 229:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line cc.y:1912
 		{
 			yyVAL.span = Span{}
 			yyVAL.syntaxs = nil
 		}
-	case 202:
-		//line cc.y:1706
+	case 230:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1917
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.syntaxs = yyS[yypt-0].syntaxs
+			yyVAL.span = yyDollar[1].span
+			yyVAL.syntaxs = yyDollar[1].syntaxs
 		}
-	case 203:
-		//line cc.y:1713
+	case 231:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1924
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.syntaxs = []Syntax{yyS[yypt-0].syntax}
+			yyVAL.span = yyDollar[1].span
+			yyVAL.syntaxs = []Syntax{yyDollar[1].syntax}
 		}
-	case 204:
-		//line cc.y:1718
+	case 232:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:1929
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.syntaxs = append(yyS[yypt-1].syntaxs, yyS[yypt-0].syntax)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.syntaxs = append(yyDollar[1].syntaxs, yyDollar[2].syntax)
 		}
-	case 205:
-		//line cc.y:1724
+	case 233:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line cc.y:1935
 		{
 			yyVAL.span = Span{}
 			yyVAL.syntaxs = nil
 		}
-	case 206:
-		//line cc.y:1729
+	case 234:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1940
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.syntaxs = yyS[yypt-0].syntaxs
+			yyVAL.span = yyDollar[1].span
+			yyVAL.syntaxs = yyDollar[1].syntaxs
 		}
-	case 207:
-		//line cc.y:1736
+	case 235:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1947
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.syntaxs = []Syntax{yyS[yypt-0].syntax}
+			yyVAL.span = yyDollar[1].span
+			yyVAL.syntaxs = []Syntax{yyDollar[1].syntax}
 		}
-	case 208:
-		//line cc.y:1741
+	case 236:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:1952
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.syntaxs = append(yyS[yypt-1].syntaxs, yyS[yypt-0].syntax)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.syntaxs = append(yyDollar[1].syntaxs, yyDollar[2].syntax)
 		}
-	case 209:
-		//line cc.y:1747
+	case 237:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line cc.y:1958
 		{
 			yyVAL.span = Span{}
 			yyVAL.syntaxs = nil
 		}
-	case 210:
-		//line cc.y:1752
+	case 238:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1963
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.syntaxs = yyS[yypt-0].syntaxs
+			yyVAL.span = yyDollar[1].span
+			yyVAL.syntaxs = yyDollar[1].syntaxs
 		}
-	case 211:
-		//line cc.y:1759
+	case 239:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1970
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.decors = nil
-			yyVAL.decors = append(yyVAL.decors, yyS[yypt-0].decor)
+			yyVAL.decors = append(yyVAL.decors, yyDollar[1].decor)
 		}
-	case 212:
-		//line cc.y:1765
+	case 240:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:1976
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.decors = append(yyS[yypt-2].decors, yyS[yypt-0].decor)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.decors = append(yyDollar[1].decors, yyDollar[3].decor)
 		}
-	case 213:
-		//line cc.y:1771
+	case 241:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line cc.y:1982
 		{
 			yyVAL.span = Span{}
 			yyVAL.decors = nil
 		}
-	case 214:
-		//line cc.y:1776
+	case 242:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1987
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.decors = yyS[yypt-0].decors
+			yyVAL.span = yyDollar[1].span
+			yyVAL.decors = yyDollar[1].decors
 		}
-	case 215:
-		//line cc.y:1783
+	case 243:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:1994
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.decls = yyS[yypt-0].decls
+			yyVAL.span = yyDollar[1].span
+			yyVAL.decls = yyDollar[1].decls
 		}
-	case 216:
-		//line cc.y:1788
+	case 244:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:1999
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.decls = append(yyS[yypt-1].decls, yyS[yypt-0].decls...)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.decls = append(yyDollar[1].decls, yyDollar[2].decls...)
 		}
-	case 217:
-		//line cc.y:1794
+	case 245:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line cc.y:2005
 		{
 			yyVAL.span = Span{}
 			yyVAL.expr = nil
 		}
-	case 218:
-		//line cc.y:1799
+	case 246:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:2010
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.expr = yyS[yypt-0].expr
+			yyVAL.span = yyDollar[1].span
+			yyVAL.expr = yyDollar[1].expr
 		}
-	case 219:
-		//line cc.y:1806
+	case 247:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:2017
 		{
-			yyVAL.span = yyS[yypt-0].span
-			yyVAL.decls = []*Decl{yyS[yypt-0].decl}
+			yyVAL.span = yyDollar[1].span
+			yyVAL.decls = []*Decl{yyDollar[1].decl}
 		}
-	case 220:
-		//line cc.y:1811
+	case 248:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line cc.y:2022
 		{
-			yyVAL.span = span(yyS[yypt-2].span, yyS[yypt-0].span)
-			yyVAL.decls = append(yyS[yypt-2].decls, yyS[yypt-0].decl)
+			yyVAL.span = span(yyDollar[1].span, yyDollar[3].span)
+			yyVAL.decls = append(yyDollar[1].decls, yyDollar[3].decl)
 		}
-	case 221:
-		//line cc.y:1818
+	case 249:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line cc.y:2029
 		{
-			yyVAL.span = yyS[yypt-0].span
+			yyVAL.span = yyDollar[1].span
 			yyVAL.syntaxs = []Syntax{
 				&StringLiteral{
 					SyntaxInfo: SyntaxInfo{Span: yyVAL.span},
-					Value:      yyS[yypt-0].str,
+					Value:      yyDollar[1].str,
 					Id:         nextId(),
 				},
 			}
 		}
-	case 222:
-		//line cc.y:1829
+	case 250:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line cc.y:2040
 		{
-			yyVAL.span = span(yyS[yypt-1].span, yyS[yypt-0].span)
-			yyVAL.syntaxs = append(yyS[yypt-1].syntaxs, &StringLiteral{
-				SyntaxInfo: SyntaxInfo{Span: yyS[yypt-0].span},
-				Value:      yyS[yypt-0].str,
+			yyVAL.span = span(yyDollar[1].span, yyDollar[2].span)
+			yyVAL.syntaxs = append(yyDollar[1].syntaxs, &StringLiteral{
+				SyntaxInfo: SyntaxInfo{Span: yyDollar[2].span},
+				Value:      yyDollar[2].str,
 				Id:         nextId(),
 			})
 		}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	cc "github.com/abduld/castdiff/cc"
+)
+
+// check implements the check subcommand: it classifies the casts in the
+// named files and reports whether any finding reaches the configured
+// severity. A PlainCast, the category of every cast nothing more is
+// known about, counts only when -categories names it, so that
+// -severity info reports the info findings of the other categories
+// rather than every cast. It prints nothing when there is no finding,
+// and returns the process exit code: 0 for a clean program, 1 if
+// findings were reported and 2 for usage or parse errors.
+func check(args []string, stderr io.Writer) int {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	severity := fs.String("severity", "warning", "lowest severity that fails the check (info, warning or error)")
+	categories := fs.String("categories", "", "comma-separated categories to check, including opt-in ones (default all others)")
+	include := fs.String("I", "", "include directory")
+	handles := fs.String("handles", "", "comma-separated typedef names of opaque handle types")
+	allow := fs.String("allow-narrowing", "", "comma-separated functions whose narrowing casts are not reported")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: castdiff check [options] *.c\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	min, err := cc.ParseSeverity(*severity)
+	if err != nil {
+		fmt.Fprintf(stderr, "castdiff: %v\n", err)
+		return 2
+	}
+	var only map[cc.CastCategory]bool
+	if *categories != "" {
+		only = map[cc.CastCategory]bool{}
+		for _, c := range strings.Split(*categories, ",") {
+			only[cc.CastCategory(strings.TrimSpace(c))] = true
+		}
+	}
+	if *include != "" {
+		cc.AddInclude(*include)
+	}
+
+	prog, err := readProg(fs.Args())
+	if err != nil {
+		fmt.Fprintf(stderr, "castdiff: %v\n", err)
+		return 2
+	}
+	env := cc.BuildEnv(prog, cc.DefaultModel)
+	if *handles != "" {
+		env.Handles = map[string]bool{}
+		for _, h := range strings.Split(*handles, ",") {
+			env.Handles[strings.TrimSpace(h)] = true
+		}
+	}
+	if *allow != "" {
+		env.AllowNarrowing = map[string]bool{}
+		for _, fn := range strings.Split(*allow, ",") {
+			env.AllowNarrowing[strings.TrimSpace(fn)] = true
+		}
+	}
+	env.Enable = only
+	n := 0
+	for _, info := range cc.ClassifyCasts(prog, env) {
+		if only != nil && !only[info.Category] || only == nil && info.Category == cc.PlainCast {
+			continue
+		}
+		if info.Category.Severity() >= min {
+			n++
+		}
+	}
+	if n > 0 {
+		fmt.Fprintf(stderr, "castdiff: %d finding(s) at or above %s\n", n, min)
+		return 1
+	}
+	return 0
+}
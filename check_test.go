@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckExitCode(t *testing.T) {
+	dir, err := ioutil.TempDir("", "castdiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "narrow.c")
+	src := "float f(double d) { return (float)d; }\n"
+	if err := ioutil.WriteFile(file, []byte(src), 0666); err != nil {
+		t.Fatal(err)
+	}
+	plain := filepath.Join(dir, "plain.c")
+	if err := ioutil.WriteFile(plain, []byte("long g(int n) { return (long)n; }\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		args []string
+		code int
+	}{
+		{[]string{file}, 1},
+		{[]string{"-severity", "error", file}, 0},
+		{[]string{"-categories", "SizeofTruncation", file}, 0},
+		{[]string{"-severity", "bogus", file}, 2},
+		{[]string{"-severity", "info", plain}, 0},
+		{[]string{"-severity", "info", "-categories", "PlainCast", plain}, 1},
+	}
+	for _, tt := range tests {
+		var stderr bytes.Buffer
+		if code := check(tt.args, &stderr); code != tt.code {
+			t.Errorf("check %v = %d, want %d (stderr %q)", tt.args, code, tt.code, stderr.String())
+		}
+		if tt.code == 0 && stderr.Len() != 0 {
+			t.Errorf("check %v printed %q on success", tt.args, stderr.String())
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	cc "github.com/abduld/castdiff/cc"
+)
+
+// compare implements the default mode. Given two files it prints the
+// casts that differ between them in the format chosen by -format,
+// leaving out changes below -min-severity; given any other number of
+// files it prints them as a single program. It returns the process
+// exit code: 0 on success and 2 for usage or parse errors.
+func compare(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("castdiff", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.String("c", "", "config file")
+	inc := fs.String("I", "", "include directory")
+	format := fs.String("format", "text", "output format ("+strings.Join(formatNames(), ", ")+")")
+	severity := fs.String("min-severity", "info", "lowest severity of the changes reported (info, warning or error)")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: castdiff [options] old.c new.c\n")
+		fmt.Fprintf(stderr, "       castdiff [options] *.c\n")
+		fmt.Fprintf(stderr, "       castdiff check [options] *.c\n")
+		fmt.Fprintf(stderr, "       castdiff diff [options] old.c new.c\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	if *inc != "" {
+		cc.AddInclude(*inc)
+	}
+	render, ok := renderers[*format]
+	if !ok {
+		fmt.Fprintf(stderr, "castdiff: unknown format %q\n", *format)
+		return 2
+	}
+	min, err := cc.ParseSeverity(*severity)
+	if err != nil {
+		fmt.Fprintf(stderr, "castdiff: %v\n", err)
+		return 2
+	}
+
+	if fs.NArg() != 2 {
+		prog, err := readProg(fs.Args())
+		if err != nil {
+			fmt.Fprintf(stderr, "castdiff: %v\n", err)
+			return 2
+		}
+		fmt.Fprintln(stdout, prog)
+		return 0
+	}
+	all, err := diffFiles(fs.Arg(0), fs.Arg(1), cc.DiffOptions{Classify: true})
+	if err != nil {
+		fmt.Fprintf(stderr, "castdiff: %v\n", err)
+		return 2
+	}
+	var changes []cc.CastChange
+	for _, c := range all {
+		if changedCast(c).Category.Severity() >= min {
+			changes = append(changes, c)
+		}
+	}
+	if err := render(stdout, changes); err != nil {
+		fmt.Fprintf(stderr, "castdiff: %v\n", err)
+		return 2
+	}
+	return 0
+}
+
+// diffFiles parses the files oldFile and newFile and returns the casts
+// that differ between them under opts. The two files are compared as
+// versions of one file, whatever their names.
+func diffFiles(oldFile, newFile string, opts cc.DiffOptions) ([]cc.CastChange, error) {
+	old, err := readProg([]string{oldFile})
+	if err != nil {
+		return nil, err
+	}
+	new, err := readProg([]string{newFile})
+	if err != nil {
+		return nil, err
+	}
+	opts.SingleFile = true
+	return cc.Diff(old, new, opts), nil
+}
+
+// formatNames returns the names of the output formats, sorted.
+func formatNames() []string {
+	var names []string
+	for name := range renderers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
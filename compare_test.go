@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompareFormats(t *testing.T) {
+	dir, err := ioutil.TempDir("", "castdiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	a := filepath.Join(dir, "a.c")
+	b := filepath.Join(dir, "b.c")
+	if err := ioutil.WriteFile(a, []byte("float f(double d) {\n\treturn (int)d;\n}\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(b, []byte("float f(double d) {\n\treturn (float)d;\n}\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) string {
+		var stdout, stderr bytes.Buffer
+		if code := compare(append(args, a, b), &stdout, &stderr); code != 0 {
+			t.Fatalf("castdiff %v = %d, want 0 (stderr %q)", args, code, stderr.String())
+		}
+		return stdout.String()
+	}
+
+	text := run()
+	if !strings.Contains(text, "b.c:2: changed (int)d to (float)d [FloatNarrowing] narrows 64-bit double to 32-bit float") || !strings.HasSuffix(text, "0 added, 0 removed, 1 changed, net +0\n") {
+		t.Errorf("text output = %q", text)
+	}
+
+	var changes []map[string]interface{}
+	if err := json.Unmarshal([]byte(run("--format=json")), &changes); err != nil {
+		t.Fatalf("json output: %v", err)
+	}
+	if len(changes) != 1 || changes[0]["kind"] != "Modified" {
+		t.Errorf("json output = %v, want one Modified change", changes)
+	}
+
+	var sarif struct {
+		Version string
+		Runs    []struct {
+			Results []struct {
+				RuleID string
+				Level  string
+			}
+		}
+	}
+	if err := json.Unmarshal([]byte(run("--format=sarif")), &sarif); err != nil {
+		t.Fatalf("sarif output: %v", err)
+	}
+	if sarif.Version != "2.1.0" || len(sarif.Runs) != 1 || len(sarif.Runs[0].Results) != 1 {
+		t.Fatalf("sarif output = %+v, want one run with one result", sarif)
+	}
+	if r := sarif.Runs[0].Results[0]; r.RuleID != "FloatNarrowing" || r.Level != "warning" {
+		t.Errorf("sarif result = %+v, want a FloatNarrowing warning", r)
+	}
+
+	if md := run("-format", "markdown"); !strings.Contains(md, "| modified | "+b+":2 | `(int)d` → `(float)d` | FloatNarrowing |") {
+		t.Errorf("markdown output = %q", md)
+	}
+
+	rows, err := csv.NewReader(strings.NewReader(run("--format=csv"))).ReadAll()
+	if err != nil {
+		t.Fatalf("csv output: %v", err)
+	}
+	if len(rows) != 2 || rows[1][0] != "modified" || rows[1][3] != "9" || rows[1][9] != "(float)d" {
+		t.Errorf("csv output = %q", rows)
+	}
+
+	if line := run("--format=compact"); line != b+":2:9: FloatNarrowing: double -> float\n" {
+		t.Errorf("compact output = %q", line)
+	}
+
+	if text := run("--min-severity=error"); text != "0 added, 0 removed, 0 changed, net +0\n" {
+		t.Errorf("text output above error = %q, want only the summary", text)
+	}
+
+	var stderr bytes.Buffer
+	if code := compare([]string{"--format=xml", a, b}, ioutil.Discard, &stderr); code != 2 {
+		t.Errorf("unknown format exits %d, want 2", code)
+	}
+}
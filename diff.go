@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	cc "github.com/abduld/castdiff/cc"
+)
+
+// diff implements the diff subcommand: it prints the casts that differ
+// between an old and a new version of a program, followed by a summary
+// line. With -delta it prints only the net number of casts added.
+func diff(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	delta := fs.Bool("delta", false, "print only the net number of casts added")
+	root := fs.String("root", "", "directory file names are reported relative to")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: castdiff diff [options] old.c new.c\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return 2
+	}
+	changes, err := diffFiles(fs.Arg(0), fs.Arg(1), cc.DiffOptions{PathRoot: *root})
+	if err != nil {
+		fmt.Fprintf(stderr, "castdiff: %v\n", err)
+		return 2
+	}
+	if *delta {
+		fmt.Fprintf(stdout, "%+d\n", cc.NetCastDelta(changes))
+		return 0
+	}
+	if err := renderText(stdout, changes); err != nil {
+		fmt.Fprintf(stderr, "castdiff: %v\n", err)
+		return 2
+	}
+	return 0
+}
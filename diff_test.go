@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiffFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "castdiff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	a := filepath.Join(dir, "old.c")
+	b := filepath.Join(dir, "new.c")
+	if err := ioutil.WriteFile(a, []byte("float f(double d) {\n\treturn (int)d;\n}\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(b, []byte("float f(double d) {\n\treturn (float)d;\n}\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := diff([]string{"-root", dir, a, b}, &stdout, &stderr); code != 0 {
+		t.Fatalf("castdiff diff = %d, want 0 (stderr %q)", code, stderr.String())
+	}
+	if out := stdout.String(); !strings.Contains(out, "new.c:2: changed (int)d to (float)d") || !strings.HasSuffix(out, "0 added, 0 removed, 1 changed, net +0\n") {
+		t.Errorf("castdiff diff output = %q, want the cast in f changed", out)
+	}
+
+	stdout.Reset()
+	if code := diff([]string{"-delta", a, b}, &stdout, &stderr); code != 0 || stdout.String() != "+0\n" {
+		t.Errorf("castdiff diff -delta = %d, %q, want 0, \"+0\\n\"", code, stdout.String())
+	}
+}
@@ -2,8 +2,6 @@
 package main
 
 import (
-	"flag"
-	"fmt"
 	"io"
 	"log"
 	"os"
@@ -11,42 +9,29 @@ import (
 	cc "github.com/abduld/castdiff/cc"
 )
 
-var (
-	cfgFile = flag.String("c", "", "config file")
-	inc     = flag.String("I", "", "include directory")
-)
-
 func main() {
 	log.SetFlags(0)
-	flag.Parse()
-	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "usage: castdiff [options] *.c\n")
-		flag.PrintDefaults()
-		os.Exit(2)
-	}
-
-	if *inc != "" {
-		cc.AddInclude(*inc)
-	}
-
-	args := flag.Args()
-	if len(args) == 0 {
-		flag.Usage()
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "check":
+			os.Exit(check(os.Args[2:], os.Stderr))
+		case "diff":
+			os.Exit(diff(os.Args[2:], os.Stdout, os.Stderr))
+		}
 	}
+	os.Exit(compare(os.Args[1:], os.Stdout, os.Stderr))
+}
 
+// readProg parses files as a single program.
+func readProg(files []string) (*cc.Prog, error) {
 	var r []io.Reader
-	files := args
 	for _, file := range files {
 		f, err := os.Open(file)
 		if err != nil {
-			log.Fatal(err)
+			return nil, err
 		}
 		r = append(r, f)
 		defer f.Close()
 	}
-	prog, err := cc.ReadMany(files, r)
-	if err != nil {
-		log.Fatal(err)
-	}
-	fmt.Println(prog)
+	return cc.ReadMany(files, r)
 }
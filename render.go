@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	cc "github.com/abduld/castdiff/cc"
+)
+
+// A renderer writes a list of cast changes in one output format.
+type renderer func(w io.Writer, changes []cc.CastChange) error
+
+// renderers are the output formats, by the name given to -format.
+var renderers = map[string]renderer{
+	"text":     renderText,
+	"json":     renderJSON,
+	"sarif":    renderSARIF,
+	"markdown": renderMarkdown,
+	"csv":      renderCSV,
+	"compact":  renderCompact,
+}
+
+// changedCast returns the cast a change is reported at: the new cast,
+// or the old one if it was removed.
+func changedCast(c cc.CastChange) *cc.CastInfo {
+	if c.New != nil {
+		return c.New
+	}
+	return c.Old
+}
+
+// describe returns a one-line description of a change.
+func describe(c cc.CastChange) string {
+	switch c.Kind {
+	case cc.Added:
+		return fmt.Sprintf("added %s", c.New.Expr)
+	case cc.Removed:
+		return fmt.Sprintf("removed %s", c.Old.Expr)
+	}
+	return fmt.Sprintf("changed %s to %s", c.Old.Expr, c.New.Expr)
+}
+
+// summary returns the closing line of the text and markdown formats.
+func summary(changes []cc.CastChange) string {
+	counts := map[cc.ChangeKind]int{}
+	for _, c := range changes {
+		counts[c.Kind]++
+	}
+	return fmt.Sprintf("%d added, %d removed, %d changed, net %+d",
+		counts[cc.Added], counts[cc.Removed], counts[cc.Modified], cc.NetCastDelta(changes))
+}
+
+// renderText prints one line per change followed by a summary line.
+func renderText(w io.Writer, changes []cc.CastChange) error {
+	for _, c := range changes {
+		info := changedCast(c)
+		line := fmt.Sprintf("%s: %s", info.Span, describe(c))
+		if info.Category != "" {
+			line += fmt.Sprintf(" [%s] %s", info.Category, info.Category.Explain(*info))
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, summary(changes))
+	return err
+}
+
+// renderJSON prints the changes as a JSON array, as described by
+// cc.JSONSchema.
+func renderJSON(w io.Writer, changes []cc.CastChange) error {
+	if changes == nil {
+		changes = []cc.CastChange{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(changes)
+}
+
+// renderMarkdown prints the changes as a table, for pasting into a
+// review comment.
+func renderMarkdown(w io.Writer, changes []cc.CastChange) error {
+	var b bytes.Buffer
+	b.WriteString("| Change | Location | Cast | Category | Explanation |\n")
+	b.WriteString("|---|---|---|---|---|\n")
+	code := func(x *cc.Expr) string {
+		return "`" + strings.Replace(x.String(), "|", `\|`, -1) + "`"
+	}
+	for _, c := range changes {
+		info := changedCast(c)
+		cast := code(info.Expr)
+		if c.Kind == cc.Modified {
+			cast = code(c.Old.Expr) + " → " + code(c.New.Expr)
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n", strings.ToLower(c.Kind.String()), info.Span, cast, info.Category, info.Category.Explain(*info))
+	}
+	fmt.Fprintf(&b, "\n%s\n", summary(changes))
+	_, err := b.WriteTo(w)
+	return err
+}
+
+// renderCSV prints one row per change, with the columns of cc.WriteCSV
+// preceded by the kind of change.
+func renderCSV(w io.Writer, changes []cc.CastChange) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"change", "file", "line", "col", "category", "severity", "fromType", "toType", "function", "snippet", "explanation"})
+	for _, c := range changes {
+		info := changedCast(c)
+		start := info.Span.Start
+		cw.Write([]string{
+			strings.ToLower(c.Kind.String()),
+			start.File,
+			strconv.Itoa(start.Line),
+			strconv.Itoa(start.Col),
+			string(info.Category),
+			info.Category.Severity().String(),
+			info.From,
+			info.To,
+			info.Func,
+			info.Expr.String(),
+			info.Category.Explain(*info),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// renderCompact prints one line per change of the form
+//
+//	file:line:col: category: fromType -> toType
+//
+// with nothing else, for grep and awk and for diffing the reports of two
+// runs. The format is stable; unclassified casts are given as PlainCast.
+func renderCompact(w io.Writer, changes []cc.CastChange) error {
+	for _, c := range changes {
+		info := changedCast(c)
+		cat := info.Category
+		if cat == "" {
+			cat = cc.PlainCast
+		}
+		start := info.Span.Start
+		if _, err := fmt.Fprintf(w, "%s:%d:%d: %s: %s -> %s\n", start.File, start.Line, start.Col, cat, info.From, info.To); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SARIF 2.1.0 output, reduced to the properties castdiff fills in.
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name  string      `json:"name"`
+		Rules []sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID string `json:"id"`
+	}
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifLocation struct {
+		PhysicalLocation struct {
+			ArtifactLocation struct {
+				URI string `json:"uri"`
+			} `json:"artifactLocation"`
+			Region struct {
+				StartLine   int `json:"startLine"`
+				StartColumn int `json:"startColumn,omitempty"`
+			} `json:"region"`
+		} `json:"physicalLocation"`
+	}
+)
+
+// sarifLevels maps severities to SARIF result levels.
+var sarifLevels = map[cc.Severity]string{
+	cc.Info:    "note",
+	cc.Warning: "warning",
+	cc.Error:   "error",
+}
+
+// renderSARIF prints the changes as a SARIF log, for code scanning
+// services. Each change is a result whose rule is the category of the
+// cast.
+func renderSARIF(w io.Writer, changes []cc.CastChange) error {
+	run := sarifRun{
+		Tool:    sarifTool{Driver: sarifDriver{Name: "castdiff", Rules: []sarifRule{}}},
+		Results: []sarifResult{},
+	}
+	seen := map[string]bool{}
+	for _, c := range changes {
+		info := changedCast(c)
+		rule := string(info.Category)
+		if rule == "" {
+			rule = string(cc.PlainCast)
+		}
+		if !seen[rule] {
+			seen[rule] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: rule})
+		}
+		var loc sarifLocation
+		loc.PhysicalLocation.ArtifactLocation.URI = info.Span.Start.File
+		loc.PhysicalLocation.Region.StartLine = info.Span.Start.Line
+		loc.PhysicalLocation.Region.StartColumn = info.Span.Start.Col
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    rule,
+			Level:     sarifLevels[cc.CastCategory(rule).Severity()],
+			Message:   sarifMessage{Text: describe(c) + ": " + cc.CastCategory(rule).Explain(*info)},
+			Locations: []sarifLocation{loc},
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}